@@ -0,0 +1,136 @@
+package lazyerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+)
+
+// LazyErrorWithAttrs - custom error structure that contains structured key-value attributes.
+type LazyErrorWithAttrs struct {
+	Err   error
+	Attrs []slog.Attr
+}
+
+// Error - error interface implementation, attributes aren't added to the message.
+func (e *LazyErrorWithAttrs) Error() string {
+	return redact(e.Err.Error())
+}
+
+// Unwrap - error interface implementation.
+func (e *LazyErrorWithAttrs) Unwrap() error {
+	return e.Err
+}
+
+// Is - error interface implementation.
+func (e *LazyErrorWithAttrs) Is(err error) bool {
+	return errors.Is(e.Err, err)
+}
+
+// Message - returns text of the wrapped error.
+func (e *LazyErrorWithAttrs) Message() string {
+	return Message(e.Err)
+}
+
+// MarshalJSON - json.Marshaler interface implementation.
+func (e *LazyErrorWithAttrs) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonError{
+		Message: Message(e.Err),
+		Attrs:   attrsMap(Attrs(e)),
+		Chain:   chain(e.Err),
+	})
+}
+
+// With - attaches key-value attributes kv to non-nil error err, kv is parsed in the same way as slog.Logger.With does.
+func With(err error, kv ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	return annotate(err, func(err error) error {
+		if t, ok := err.(*LazyErrorWithAttrs); ok {
+			return &LazyErrorWithAttrs{
+				Err:   t.Err,
+				Attrs: append(t.Attrs[:len(t.Attrs):len(t.Attrs)], newAttrs(kv)...),
+			}
+		}
+
+		return &LazyErrorWithAttrs{
+			Err:   err,
+			Attrs: newAttrs(kv),
+		}
+	})
+}
+
+// annotate - wraps error err with wrap beneath caller, stack and panic wrappers, so these are preserved
+// and Try doesn't wrap err again. Wrappers are copied, since err can be shared.
+func annotate(err error, wrap func(err error) error) error {
+	switch t := err.(type) {
+	case *LazyErrorWithCaller:
+		e := *t
+		e.Err = annotate(t.Err, wrap)
+
+		return &e
+	case *LazyErrorWithStack:
+		e := *t
+		e.Err = annotate(t.Err, wrap)
+
+		return &e
+	case *LazyErrorFromPanic:
+		e := *t
+		e.err = annotate(t.Unwrap(), wrap)
+
+		return &e
+	default:
+		return wrap(err)
+	}
+}
+
+// TryWith - attaches key-value attributes kv to non-nil error err with With and checks it with Try.
+func TryWith(err error, kv ...interface{}) {
+	if err != nil {
+		Try(With(err, kv...))
+	}
+}
+
+// Attrs - returns attributes attached to error err and errors wrapped by it (branches of joined errors included),
+// outermost first, context values snapshotted with WithContext included.
+func Attrs(err error) []slog.Attr {
+	var attrs []slog.Attr
+
+	walk(err, func(err error) bool {
+		switch e := err.(type) {
+		case *LazyErrorWithAttrs:
+			attrs = append(attrs, e.Attrs...)
+		case *LazyErrorWithContext:
+			attrs = append(attrs, e.Values...)
+		}
+
+		return true
+	})
+
+	return attrs
+}
+
+// newAttrs - parses key-value pairs kv into attributes.
+func newAttrs(kv []interface{}) []slog.Attr {
+	return slog.Group("", kv...).Value.Group()
+}
+
+// attrsMap - converts attributes into a map for JSON output, groups become nested maps.
+func attrsMap(attrs []slog.Attr) map[string]interface{} {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	m := make(map[string]interface{}, len(attrs))
+	for _, attr := range attrs {
+		if value := attr.Value.Resolve(); value.Kind() == slog.KindGroup {
+			m[attr.Key] = attrsMap(value.Group())
+		} else {
+			m[attr.Key] = value.Any()
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,91 @@
+package lazyerrors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWith(t *testing.T) {
+	if With(nil, "key", "value") != nil {
+		t.Fatal("unexpected: non-nil error")
+	}
+
+	err := testWrapper(Try, Catch, func() error {
+		TryWith(With(io.EOF, "user_id", 42), "op", "checkout")
+
+		return nil
+	})
+
+	attrs := Attrs(err)
+	if !errors.Is(err, io.EOF) || len(attrs) != 2 || attrs[0].Key != "user_id" || attrs[1].Value.String() != "checkout" {
+		t.Fatal("unexpected:", err, attrs)
+	} else {
+		fmt.Println(err, attrs)
+	}
+
+	if callerEnabled && strings.Count(err.Error(), "lazy_attrs_test.go") != 1 {
+		t.Fatal("unexpected:", err)
+	}
+
+	// attributes are put beneath the caller wrapper of an already wrapped error.
+	wrapped := With(err, "retry", true)
+	if _, ok := wrapped.(*LazyErrorWithCaller); !ok || len(Attrs(wrapped)) != 3 || len(Attrs(err)) != 2 {
+		t.Fatal("unexpected:", wrapped, Attrs(wrapped))
+	}
+}
+
+func TestAnnotatePanic(t *testing.T) {
+	panicked := testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, testFuncPanic)
+
+	// annotations are put beneath the panic wrapper, so it isn't wrapped again and stays unchanged.
+	err := WithContext(context.Background(), WithClass(With(panicked, "user_id", 42), io.EOF))
+	if _, ok := err.(*LazyErrorFromPanic); !ok || !errors.Is(err, ErrPanic) || !errors.Is(err, io.EOF) || len(Attrs(err)) != 1 {
+		t.Fatal("unexpected:", err, Attrs(err))
+	}
+
+	if errors.Is(panicked, io.EOF) || len(Attrs(panicked)) != 0 {
+		t.Fatal("unexpected:", panicked)
+	}
+
+	if rethrown := testWrapper(Try, Catch, func() error { return err }); rethrown != err {
+		t.Fatal("unexpected:", rethrown)
+	}
+
+	if data, jsonErr := MarshalError(err); jsonErr != nil || !strings.Contains(string(data), `"attrs":{"user_id":42}`) {
+		t.Fatal("unexpected:", string(data), jsonErr)
+	}
+}
+
+func TestWithOutput(t *testing.T) {
+	err := testWrapper(Try, Catch, func() error {
+		TryWith(io.EOF, "user_id", 42, "op", "checkout")
+
+		return nil
+	})
+
+	data, jsonErr := json.Marshal(err)
+	if jsonErr != nil || !strings.Contains(string(data), `"attrs":{"op":"checkout","user_id":42}`) {
+		t.Fatal("unexpected:", string(data), jsonErr)
+	} else {
+		fmt.Println(string(data))
+	}
+
+	var keys []string
+	for _, attr := range LogAttrs(err) {
+		keys = append(keys, attr.Key)
+	}
+
+	want := "error,user_id,op"
+	if callerEnabled {
+		want = "error,caller,user_id,op"
+	}
+
+	if strings.Join(keys, ",") != want {
+		t.Fatal("unexpected:", keys)
+	}
+}
@@ -0,0 +1,78 @@
+package lazyerrors
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+type (
+	// Backoff - strategy of delays between attempts of Retry.
+	Backoff interface {
+		// Delay - returns delay before the next attempt after attempt number attempt (starting with 1) has failed.
+		Delay(attempt int) time.Duration
+	}
+	// BackoffFunc - adapter of ordinary functions to Backoff.
+	BackoffFunc func(attempt int) time.Duration
+)
+
+// Delay - Backoff interface implementation.
+func (f BackoffFunc) Delay(attempt int) time.Duration {
+	return f(attempt)
+}
+
+// ConstantBackoff - returns Backoff delaying every attempt by d.
+func ConstantBackoff(d time.Duration) Backoff {
+	return BackoffFunc(func(int) time.Duration {
+		return d
+	})
+}
+
+// ExponentialBackoff - returns Backoff doubling delay base with every attempt, capped by limit unless it's 0.
+func ExponentialBackoff(base, limit time.Duration) Backoff {
+	return BackoffFunc(func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt && d > 0 && (limit == 0 || d < limit); i++ {
+			if d > math.MaxInt64/2 {
+				return math.MaxInt64
+			}
+
+			d *= 2
+		}
+
+		if limit > 0 && d > limit {
+			d = limit
+		}
+
+		return d
+	})
+}
+
+// JitterBackoff - returns Backoff randomizing delays of b within [0, delay), so concurrent retries spread out.
+func JitterBackoff(b Backoff) Backoff {
+	return BackoffFunc(func(attempt int) time.Duration {
+		if d := b.Delay(attempt); d > 0 {
+			return time.Duration(rand.Int63n(int64(d)))
+		}
+
+		return 0
+	})
+}
+
+// sleep - waits for duration d, returns false if ctx is done before.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
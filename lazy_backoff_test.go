@@ -0,0 +1,60 @@
+package lazyerrors
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	exponential := ExponentialBackoff(time.Millisecond, 5*time.Millisecond)
+	for attempt, want := range []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 5 * time.Millisecond, 5 * time.Millisecond} {
+		if d := exponential.Delay(attempt + 1); d != want {
+			t.Fatal("unexpected:", attempt+1, d)
+		}
+	}
+
+	if d := ExponentialBackoff(time.Hour, 0).Delay(100); d <= 0 {
+		t.Fatal("unexpected:", d)
+	}
+
+	if d := ConstantBackoff(time.Second).Delay(10); d != time.Second {
+		t.Fatal("unexpected:", d)
+	}
+
+	jitter := JitterBackoff(ConstantBackoff(time.Second))
+	for i := 0; i < 100; i++ {
+		if d := jitter.Delay(1); d < 0 || d >= time.Second {
+			t.Fatal("unexpected:", d)
+		}
+	}
+
+	if d := JitterBackoff(ConstantBackoff(0)).Delay(1); d != 0 {
+		t.Fatal("unexpected:", d)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	var delays []int
+
+	backoff := BackoffFunc(func(attempt int) time.Duration {
+		delays = append(delays, attempt)
+
+		return time.Millisecond
+	})
+
+	if err := Retry(3, testFuncError, WithBackoff(backoff)); err == nil || len(delays) != 2 || delays[1] != 2 {
+		t.Fatal("unexpected:", err, delays)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	start := time.Now()
+
+	err := RetryCtx(ctx, 3, func() error { calls++; return testFuncError() }, WithBackoff(ConstantBackoff(time.Hour)))
+	if err == nil || calls != 1 || time.Since(start) > time.Minute {
+		t.Fatal("unexpected:", err, calls)
+	}
+}
@@ -0,0 +1,217 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen - error returned by Breaker for operations failing fast while their circuit is open.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// BreakerState - state of a circuit of Breaker.
+type BreakerState uint8
+
+const (
+	// BreakerClosed - calls pass through, consecutive failures are counted.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen - calls fail fast with ErrCircuitOpen until the cooldown passes.
+	BreakerOpen
+	// BreakerHalfOpen - a single probing call is let through, its outcome closes or reopens the circuit.
+	BreakerHalfOpen
+)
+
+// breakerStates - names of breaker states.
+var breakerStates = [...]string{
+	BreakerClosed:   "closed",
+	BreakerOpen:     "open",
+	BreakerHalfOpen: "half-open",
+}
+
+// String - fmt.Stringer interface implementation.
+func (s BreakerState) String() string {
+	if int(s) < len(breakerStates) {
+		return breakerStates[s]
+	}
+
+	return fmt.Sprintf("BreakerState(%d)", s)
+}
+
+// Breaker - circuit breaker tracking failures per named operation, safe for concurrent use.
+//
+// The circuit of an operation opens after threshold consecutive failures, after cooldown a single call probes it.
+//
+//	var breaker = lazyerrors.NewBreaker(5, time.Minute)
+//
+//	func query() (err error) {
+//	        defer lazyerrors.Catch(&err)
+//	        lazyerrors.Try(breaker.Do("db", exec))
+//
+//	        return
+//	}
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+	mu        sync.Mutex
+	circuits  map[string]*circuit
+	// running - numbers of calls of operations in flight in Do, so Observe skips their errors.
+	running map[string]int
+}
+
+// circuit - state of a single operation of Breaker.
+type circuit struct {
+	state    BreakerState
+	failures int
+	opened   time.Time
+	probing  bool
+}
+
+// NewBreaker - returns Breaker opening circuits after threshold (at least 1) consecutive failures for cooldown.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{
+		threshold: max(threshold, 1),
+		cooldown:  cooldown,
+		circuits:  make(map[string]*circuit),
+		running:   make(map[string]int),
+	}
+}
+
+// Allow - returns error wrapping ErrCircuitOpen if the circuit of operation name is open, nil otherwise,
+// so it can be checked with Try. Once cooldown passes, the first call is allowed to probe the circuit.
+func (b *Breaker) Allow(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(name)
+
+	if c.state == BreakerOpen && time.Since(c.opened) >= b.cooldown {
+		c.state = BreakerHalfOpen
+	}
+
+	switch {
+	case c.state == BreakerClosed:
+		return nil
+	case c.state == BreakerHalfOpen && !c.probing:
+		c.probing = true
+
+		return nil
+	default:
+		return fmt.Errorf("%s: %w", name, ErrCircuitOpen)
+	}
+}
+
+// Success - records successful call of operation name, the circuit is closed.
+func (b *Breaker) Success(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	*b.circuit(name) = circuit{}
+}
+
+// Failure - records failed call of operation name, the circuit is opened if the threshold is reached or it was probed.
+func (b *Breaker) Failure(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(name)
+	c.failures++
+	c.probing = false
+
+	if c.state == BreakerHalfOpen || c.failures >= b.threshold {
+		c.state = BreakerOpen
+		c.opened = time.Now()
+	}
+}
+
+// State - returns state of the circuit of operation name.
+func (b *Breaker) State(name string) BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c, ok := b.circuits[name]; ok {
+		return c.state
+	}
+
+	return BreakerClosed
+}
+
+// Do - runs fn under Catch unless the circuit of operation name is open, records its outcome and returns its error.
+//
+// The outcome is recorded even if the catch handler doesn't recover, and errors of fn aren't recorded by Observe again.
+func (b *Breaker) Do(name string, fn func() error) error {
+	if err := b.Allow(name); err != nil {
+		return err
+	}
+
+	b.enter(name, 1)
+	defer b.enter(name, -1)
+
+	return attempt(func() error { return b.run(name, fn) })
+}
+
+// enter - adds delta to the number of calls of operation name in flight in Do.
+func (b *Breaker) enter(name string, delta int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.running[name] += delta
+
+	if b.running[name] == 0 {
+		delete(b.running, name)
+	}
+}
+
+// run - calls fn and records its outcome for operation name, panics are recorded as failures and keep panicking.
+func (b *Breaker) run(name string, fn func() error) (err error) {
+	defer func() {
+		r := recover()
+		if r != nil || err != nil {
+			b.Failure(name)
+		} else {
+			b.Success(name)
+		}
+
+		if r != nil {
+			panic(r)
+		}
+	}()
+
+	return fn()
+}
+
+// Observe - returns OnCatch hook recording caught errors as failures of operations named by fn, so Breaker
+// follows failures of code it doesn't wrap. Errors fn returns empty name for and errors of operations
+// with calls in flight in Do (recorded by Do) are skipped.
+//
+//	lazyerrors.RegisterOnCatch(breaker.Observe(operationOf))
+func (b *Breaker) Observe(fn func(err error) string) func(err error, recovered bool) {
+	return func(err error, _ bool) {
+		if errors.Is(err, ErrCircuitOpen) {
+			return
+		}
+
+		if name := fn(err); name != "" && !b.isRunning(name) {
+			b.Failure(name)
+		}
+	}
+}
+
+// isRunning - reports whether operation name has calls in flight in Do.
+func (b *Breaker) isRunning(name string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.running[name] > 0
+}
+
+// circuit - returns circuit of operation name, creating it if needed. Must be called with b.mu locked.
+func (b *Breaker) circuit(name string) *circuit {
+	c, ok := b.circuits[name]
+	if !ok {
+		c = &circuit{}
+		b.circuits[name] = c
+	}
+
+	return c
+}
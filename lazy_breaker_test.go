@@ -0,0 +1,145 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	b := NewBreaker(2, 10*time.Millisecond)
+
+	calls := 0
+	failing := func() error { calls++; return testFuncError() }
+
+	for i := 0; i < 2; i++ {
+		if err := b.Do("db", failing); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatal("unexpected:", err)
+		}
+	}
+
+	if state := b.State("db"); state != BreakerOpen {
+		t.Fatal("unexpected:", state)
+	}
+
+	err := Do(func() { Try(b.Do("db", failing)) })
+	if !errors.Is(err, ErrCircuitOpen) || calls != 2 {
+		t.Fatal("unexpected:", err, calls)
+	} else {
+		fmt.Println(err)
+	}
+
+	if state := b.State("cache"); state != BreakerClosed || b.Allow("cache") != nil {
+		t.Fatal("unexpected:", state)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if err := b.Allow("db"); err != nil || b.State("db") != BreakerHalfOpen {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := b.Allow("db"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("unexpected:", err)
+	}
+
+	b.Failure("db")
+
+	if state := b.State("db"); state != BreakerOpen {
+		t.Fatal("unexpected:", state)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if err := b.Do("db", testFuncPanic); !errors.Is(err, ErrPanic) || b.State("db") != BreakerOpen {
+		t.Fatal("unexpected:", err, b.State("db"))
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if err := b.Do("db", testFuncNoError); err != nil || b.State("db") != BreakerClosed {
+		t.Fatal("unexpected:", err, b.State("db"))
+	}
+}
+
+func TestBreakerObserve(t *testing.T) {
+	defer ResetHooks()
+
+	b := NewBreaker(1, time.Minute)
+
+	RegisterOnCatch(b.Observe(func(err error) string {
+		if errors.Is(err, ErrPanic) {
+			return "worker"
+		}
+
+		return ""
+	}))
+
+	_ = testWrapper(Try, Catch, testFuncError)
+
+	if state := b.State("worker"); state != BreakerClosed {
+		t.Fatal("unexpected:", state)
+	}
+
+	_ = testWrapper(Try, Catch, testFuncPanic)
+
+	if state := b.State("worker"); state != BreakerOpen || state.String() != "open" {
+		t.Fatal("unexpected:", state)
+	}
+
+	if s := BreakerState(42).String(); s != "BreakerState(42)" {
+		t.Fatal("unexpected:", s)
+	}
+}
+
+func TestBreakerDoObserve(t *testing.T) {
+	defer ResetHooks()
+
+	b := NewBreaker(2, time.Minute)
+
+	RegisterOnCatch(b.Observe(func(error) string { return "db" }))
+
+	_ = b.Do("db", func() error { Try(testFuncError()); return nil })
+
+	if state := b.State("db"); state != BreakerClosed {
+		t.Fatal("unexpected:", state)
+	}
+
+	_ = testWrapper(Try, Catch, testFuncError)
+
+	if state := b.State("db"); state != BreakerOpen {
+		t.Fatal("unexpected:", state)
+	}
+}
+
+func TestBreakerProbeRepanic(t *testing.T) {
+	defer SetDefaultCatch(nil)
+
+	b := NewBreaker(1, time.Millisecond)
+	b.Failure("db")
+
+	time.Sleep(5 * time.Millisecond)
+
+	SetDefaultCatch(CatchErrorHandler)
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("unexpected: no panic")
+			}
+		}()
+
+		_ = b.Do("db", testFuncPanic)
+	}()
+
+	if state := b.State("db"); state != BreakerOpen {
+		t.Fatal("unexpected:", state)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if err := b.Allow("db"); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !lazyerrors_nocaller
+
+package lazyerrors
+
+// callerEnabled - caller capture is compiled in, build with lazyerrors_nocaller tag to disable it.
+const callerEnabled = true
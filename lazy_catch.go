@@ -0,0 +1,304 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"runtime/debug"
+)
+
+// CatchOption - catch policy used by CatchChain, returns the error for the next policy or nil to suppress it.
+type CatchOption func(err error) error
+
+// CatchOnly - catches only thrown errors matching one of targets via errors.Is, anything else keeps panicking.
+func CatchOnly(ep *error, targets ...error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		// if a matching error was thrown, assign it through the pointer and return.
+		if err, ok := r.(error); ok && isAny(err, targets) {
+			*ep = err
+			notifyCatch(err, isPanic(r))
+
+			return
+		}
+		// else continue panicking.
+		panic(r)
+	}
+}
+
+// CatchAs - catches thrown error or panic like CatchAllWithStackFunc and populates tp if the error matches T.
+func CatchAs[T error](tp *T, ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		*ep = recoveredError(r)
+		// populate the typed target if possible.
+		if tp != nil {
+			errors.As(*ep, tp)
+		}
+	}
+}
+
+// CatchIf - catches thrown error or panic only if pred reports true for it, anything else keeps panicking.
+func CatchIf(ep *error, pred func(error) bool) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		// if the predicate accepts the error, assign it through the pointer and return.
+		if err := toError(r); pred(err) {
+			*ep = err
+			notifyCatch(err, isPanic(r))
+
+			return
+		}
+		// else continue panicking.
+		panic(r)
+	}
+}
+
+// CatchTransform - catches thrown error or panic like CatchAllWithStackFunc and assigns it mapped with fn.
+func CatchTransform(ep *error, fn func(error) error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		*ep = fn(recoveredError(r))
+	}
+}
+
+// CatchHandle - catches thrown error or panic like CatchAllWithStackFunc and passes it to fn.
+func CatchHandle(fn func(error)) {
+	if fn == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		fn(recoveredError(r))
+	}
+}
+
+// CatchWrap - catches thrown error or panic like CatchAllWithStackFunc and wraps it with a formatted message.
+//
+// Message is formatted only when something was recovered.
+func CatchWrap(ep *error, format string, args ...interface{}) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		*ep = fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), recoveredError(r))
+	}
+}
+
+// CatchIgnore - catches thrown error or panic like CatchAllWithStackFunc, errors matching targets are set to nil.
+func CatchIgnore(ep *error, targets ...error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		// if an expected error was thrown, suppress it.
+		if err := recoveredError(r); !isAny(err, targets) {
+			*ep = err
+		} else {
+			*ep = nil
+		}
+	}
+}
+
+// CatchJoin - catches thrown error or panic like CatchAllWithStackFunc, but doesn't overwrite an existing error.
+//
+// If *ep already holds an error, both errors are joined with errors.Join, else the caught error is assigned as is.
+func CatchJoin(ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		if *ep == nil {
+			*ep = recoveredError(r)
+		} else {
+			*ep = errors.Join(*ep, recoveredError(r))
+		}
+	}
+}
+
+// CatchAppend - catches thrown error or panic like CatchJoin, but keeps the accumulated errors flat.
+//
+// If *ep is nil, the caught error is assigned as is. If *ep was produced by errors.Join (e.g. by previous
+// CatchAppend scopes), the caught error is appended to its errors instead of nesting joins.
+func CatchAppend(ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		*ep = appendError(*ep, recoveredError(r))
+	}
+}
+
+// CatchChain - catches thrown error or panic like CatchAllWithStackFunc and applies handlers in order.
+//
+// The chain stops as soon as a handler returns nil, the result of the last handler is assigned through the pointer.
+func CatchChain(ep *error, handlers ...CatchOption) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		err := recoveredError(r)
+
+		for _, handler := range handlers {
+			if err = handler(err); err == nil {
+				break
+			}
+		}
+
+		*ep = err
+	}
+}
+
+// CatchObserve - passes thrown error or panic with current stack to fn and continues panicking.
+func CatchObserve(fn func(err error, stack []byte)) {
+	if fn == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		stack := debug.Stack()
+		fn(toError(r), stack)
+		// continue panicking.
+		panic(r)
+	}
+}
+
+// CatchToWriter - catches thrown error or panic like CatchAllWithStackFunc and writes its report to w.
+func CatchToWriter(w io.Writer, ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		*ep = recoveredError(r)
+		WriteReport(w, *ep)
+	}
+}
+
+// CatchNotify - catches thrown error or panic like CatchAllWithStackFunc and sends it to ch (send may block).
+func CatchNotify(ch chan<- error) {
+	if ch == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		ch <- recoveredError(r)
+	}
+}
+
+// Rethrow - resumes propagation of non-nil error err from custom catch logic, the error is thrown as is.
+//
+// Like Try, it passes err to OnTry hooks.
+func Rethrow(err error) {
+	if err != nil {
+		throw(err)
+	}
+}
+
+// Ignore - returns CatchOption that suppresses errors matching one of targets via errors.Is.
+func Ignore(targets ...error) CatchOption {
+	return func(err error) error {
+		if isAny(err, targets) {
+			return nil
+		}
+
+		return err
+	}
+}
+
+// Observe - returns CatchOption that passes errors to fn and keeps them as is.
+func Observe(fn func(error)) CatchOption {
+	return func(err error) error {
+		fn(err)
+
+		return err
+	}
+}
+
+// FromRecovered - converts value r returned by recover into an error like CatchAllWithStackFunc, nil stays nil.
+//
+// It's meant for catch handlers written outside of this package, as recover must be called by them directly.
+func FromRecovered(r interface{}) error {
+	if r == nil {
+		return nil
+	}
+
+	return recoveredError(r)
+}
+
+// recoveredError - converts recovered r into an error with toError and passes it to OnCatch hooks.
+func recoveredError(r interface{}) error {
+	err := toError(r)
+	notifyCatch(err, isPanic(r))
+
+	return err
+}
+
+// toError - returns recovered r as is if it's an error, else wraps it into LazyErrorFromPanic with stack.
+//
+// Runtime errors are wrapped into LazyErrorFromPanic as well.
+func toError(r interface{}) error {
+	if !isPanic(r) {
+		return r.(error)
+	}
+
+	return newErrorFromPanic(r)
+}
+
+// isPanic - reports whether recovered r is a panic rather than a thrown error (runtime errors are panics).
+func isPanic(r interface{}) bool {
+	err, ok := r.(error)
+
+	return !ok || isRuntimeError(err)
+}
+
+// isAny - reports whether err matches any of targets via errors.Is.
+func isAny(err error, targets []error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// joinErrorType - type of errors produced by errors.Join.
+var joinErrorType = reflect.TypeOf(errors.Join(ErrPanic))
+
+// appendError - appends error err to error errs, errors produced by errors.Join are flattened.
+func appendError(errs, err error) error {
+	if errs == nil {
+		return err
+	}
+
+	if err == nil {
+		return errs
+	}
+
+	if reflect.TypeOf(errs) == joinErrorType {
+		joined := errs.(interface{ Unwrap() []error }).Unwrap()
+
+		return errors.Join(append(joined[:len(joined):len(joined)], err)...)
+	}
+
+	return errors.Join(errs, err)
+}
@@ -0,0 +1,379 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCatchOnly(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			panic("this should panic")
+		} else {
+			fmt.Println("as expected:", r)
+		}
+	}()
+
+	var err error
+
+	func() {
+		defer CatchOnly(&err, io.ErrUnexpectedEOF, io.EOF)
+		Try(io.EOF)
+	}()
+
+	if !errors.Is(err, io.EOF) {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+	// this should panic.
+	func() {
+		defer CatchOnly(&err, io.EOF)
+		Try(testFuncError())
+	}()
+}
+
+func TestCatchAs(t *testing.T) {
+	var (
+		err     error
+		pathErr *fs.PathError
+	)
+
+	func() {
+		defer CatchAs(&pathErr, &err)
+		Try1(os.Open("/nonexistent/lazyerrors"))
+	}()
+
+	if err == nil || pathErr == nil || pathErr.Op != "open" {
+		t.Fatal("unexpected:", err, pathErr)
+	} else {
+		fmt.Println(err)
+	}
+
+	pathErr = nil
+
+	func() {
+		defer CatchAs(&pathErr, &err)
+		Try(testFuncPanic())
+	}()
+
+	if !errors.Is(err, ErrPanic) || pathErr != nil {
+		t.Fatal("unexpected:", err, pathErr)
+	}
+}
+
+func TestCatchIf(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			panic("this should panic")
+		} else {
+			fmt.Println("as expected:", r)
+		}
+	}()
+
+	var err error
+
+	isEOF := func(err error) bool {
+		return errors.Is(err, io.EOF)
+	}
+
+	func() {
+		defer CatchIf(&err, isEOF)
+		Try(io.EOF)
+	}()
+
+	if !errors.Is(err, io.EOF) {
+		t.Fatal("unexpected:", err)
+	}
+	// this should panic.
+	func() {
+		defer CatchIf(&err, isEOF)
+		Try(testFuncPanic())
+	}()
+}
+
+func TestCatchTransform(t *testing.T) {
+	errDomain := errors.New("domain error")
+
+	var err error
+
+	func() {
+		defer CatchTransform(&err, func(err error) error {
+			return fmt.Errorf("%w: %v", errDomain, errors.Unwrap(err))
+		})
+		Try(testFuncError())
+	}()
+
+	if !errors.Is(err, errDomain) {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestCatchHandle(t *testing.T) {
+	var handled []error
+
+	handle := func(err error) {
+		handled = append(handled, err)
+	}
+
+	for _, f := range []func() error{testFuncNoError, testFuncError, testFuncPanic} {
+		func() {
+			defer CatchHandle(handle)
+			Try(f())
+		}()
+	}
+
+	if len(handled) != 2 || !errors.Is(handled[1], ErrPanic) {
+		t.Fatal("unexpected:", handled)
+	} else {
+		fmt.Println(handled)
+	}
+}
+
+func TestCatchWrap(t *testing.T) {
+	var err error
+
+	func() {
+		defer CatchWrap(&err, "loading user %d", 42)
+		Try(io.EOF)
+	}()
+
+	if !errors.Is(err, io.EOF) || !strings.HasPrefix(err.Error(), "loading user 42: ") {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestCatchIgnore(t *testing.T) {
+	err := io.ErrClosedPipe
+
+	func() {
+		defer CatchIgnore(&err, io.EOF)
+		Try(io.EOF)
+	}()
+
+	if err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	func() {
+		defer CatchIgnore(&err, io.EOF)
+		Try(testFuncPanic())
+	}()
+
+	if !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestCatchJoin(t *testing.T) {
+	err := io.ErrClosedPipe
+
+	func() {
+		defer CatchJoin(&err)
+		Try(io.EOF)
+	}()
+
+	if !errors.Is(err, io.EOF) || !errors.Is(err, io.ErrClosedPipe) {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	err = nil
+
+	func() {
+		defer CatchJoin(&err)
+		Try(io.EOF)
+	}()
+
+	if _, ok := err.(*LazyErrorWithCaller); !ok || !errors.Is(err, io.EOF) {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestCatchAppend(t *testing.T) {
+	var err error
+
+	for _, f := range []func() error{testFuncError, testFuncNoError, testFuncPanic, testFuncError} {
+		func() {
+			defer CatchAppend(&err)
+			Try(f())
+		}()
+	}
+
+	errs, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(errs.Unwrap()) != 3 || !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	err = nil
+
+	func() {
+		defer CatchAppend(&err)
+		Try(io.EOF)
+	}()
+
+	if _, ok := err.(*LazyErrorWithCaller); !ok {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestCatchChain(t *testing.T) {
+	var (
+		err      error
+		observed []error
+	)
+
+	errDomain := errors.New("domain error")
+
+	handlers := []CatchOption{
+		Ignore(io.EOF),
+		func(err error) error {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return errDomain
+			}
+
+			return err
+		},
+		Observe(func(err error) { observed = append(observed, err) }),
+	}
+
+	for _, target := range []error{io.EOF, io.ErrUnexpectedEOF, io.ErrClosedPipe} {
+		func() {
+			defer CatchChain(&err, handlers...)
+			Try(target)
+		}()
+	}
+
+	if !errors.Is(err, io.ErrClosedPipe) || len(observed) != 2 || observed[0] != errDomain {
+		t.Fatal("unexpected:", err, observed)
+	} else {
+		fmt.Println(observed)
+	}
+}
+
+func TestCatchObserve(t *testing.T) {
+	var (
+		err      error
+		observed error
+		stack    []byte
+	)
+
+	func() {
+		defer CatchAllFunc(&err)
+		defer CatchObserve(func(err error, s []byte) { observed, stack = err, s })
+		Try(io.EOF)
+	}()
+
+	if !errors.Is(err, io.EOF) || !errors.Is(observed, io.EOF) || len(stack) == 0 {
+		t.Fatal("unexpected:", err, observed)
+	} else {
+		fmt.Println(observed)
+	}
+}
+
+func TestRethrow(t *testing.T) {
+	defer ResetHooks()
+
+	var (
+		err    error
+		thrown int
+	)
+
+	RegisterOnTry(func(error) { thrown++ })
+
+	customCatch := func() {
+		if r := recover(); r != nil {
+			if err := recoveredError(r); !errors.Is(err, io.EOF) {
+				Rethrow(err)
+			}
+		}
+	}
+
+	func() {
+		defer CatchAllFunc(&err)
+		func() {
+			defer customCatch()
+			Try(io.ErrClosedPipe)
+		}()
+	}()
+
+	if !errors.Is(err, io.ErrClosedPipe) || thrown != 2 {
+		t.Fatal("unexpected:", err, thrown)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestCatchToWriter(t *testing.T) {
+	var (
+		err error
+		buf strings.Builder
+	)
+
+	for _, f := range []func() error{testFuncNoError, testFuncError, testFuncPanic} {
+		func() {
+			defer CatchToWriter(&buf, &err)
+			Try(f())
+		}()
+	}
+
+	if report := buf.String(); !strings.Contains(report, "caller: ") || !strings.Contains(report, "stack:\n") {
+		t.Fatal("unexpected:", report)
+	} else {
+		fmt.Println(report)
+	}
+}
+
+func TestCatchNotify(t *testing.T) {
+	errs := make(chan error, 3)
+
+	for _, f := range []func() error{testFuncNoError, testFuncError, testFuncPanic} {
+		go func(f func() error) {
+			defer func() { errs <- nil }()
+			defer CatchNotify(errs)
+			Try(f())
+		}(f)
+	}
+
+	var caught []error
+
+	for i := 0; i < 3; {
+		if err := <-errs; err != nil {
+			caught = append(caught, err)
+		} else {
+			i++
+		}
+	}
+
+	if len(caught) != 2 {
+		t.Fatal("unexpected:", caught)
+	} else {
+		fmt.Println(caught)
+	}
+}
+
+func TestFromRecovered(t *testing.T) {
+	if err := FromRecovered(nil); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := FromRecovered(io.EOF); err != io.EOF {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := FromRecovered("test panic"); !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+}
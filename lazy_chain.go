@@ -0,0 +1,100 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+type (
+	// LazyChain - sequence of fallible steps executed one by one until the first failure.
+	LazyChain struct {
+		steps []chainStep
+	}
+	// LazyErrorWithStep - custom error structure that contains information about a failed chain step.
+	LazyErrorWithStep struct {
+		Err   error
+		Name  string
+		Index int
+	}
+	// chainStep - single named step of LazyChain.
+	chainStep struct {
+		name string
+		fn   func() error
+	}
+)
+
+// Error - error interface implementation.
+func (e *LazyErrorWithStep) Error() string {
+	if e.Name != "" {
+		return redact(fmt.Sprintf("step %d (%s): %v", e.Index, e.Name, e.Err))
+	}
+
+	return redact(fmt.Sprintf("step %d: %v", e.Index, e.Err))
+}
+
+// Unwrap - error interface implementation.
+func (e *LazyErrorWithStep) Unwrap() error {
+	return e.Err
+}
+
+// Is - error interface implementation.
+func (e *LazyErrorWithStep) Is(err error) bool {
+	return errors.Is(e.Err, err)
+}
+
+// Chain - returns an empty LazyChain.
+func Chain() *LazyChain {
+	return &LazyChain{}
+}
+
+// Then - appends an unnamed step fn to the chain.
+func (c *LazyChain) Then(fn func() error) *LazyChain {
+	return c.ThenNamed("", fn)
+}
+
+// ThenNamed - appends a step fn with a given name to the chain.
+func (c *LazyChain) ThenNamed(name string, fn func() error) *LazyChain {
+	c.steps = append(c.steps, chainStep{name: name, fn: fn})
+
+	return c
+}
+
+// Run - executes steps under Catch until one fails, the failure is wrapped into LazyErrorWithStep with caller,
+// unless it already carries one.
+func (c *LazyChain) Run() error {
+	for i, step := range c.steps {
+		if err := step.run(); err != nil {
+			stepErr := &LazyErrorWithStep{
+				Err:   err,
+				Name:  step.name,
+				Index: i,
+			}
+
+			if hasCaller(err) {
+				return stepErr
+			}
+
+			return NewErrorWithCaller(stepErr)
+		}
+	}
+
+	return nil
+}
+
+// run - executes a step under Catch, so Try can be used inside of it.
+func (s chainStep) run() (err error) {
+	defer Catch(&err)
+
+	return s.fn()
+}
+
+// hasCaller - reports whether err is or wraps a lazy error carrying a caller or a stack.
+func hasCaller(err error) bool {
+	var (
+		withCaller *LazyErrorWithCaller
+		withStack  *LazyErrorWithStack
+		fromPanic  *LazyErrorFromPanic
+	)
+
+	return errors.As(err, &withCaller) || errors.As(err, &withStack) || errors.As(err, &fromPanic)
+}
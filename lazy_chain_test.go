@@ -0,0 +1,54 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	var steps []int
+
+	step := func(i int) func() error {
+		return func() error {
+			steps = append(steps, i)
+
+			return nil
+		}
+	}
+
+	if err := Chain().Then(step(0)).Then(step(1)).Run(); err != nil || len(steps) != 2 {
+		t.Fatal("unexpected:", steps, err)
+	}
+
+	customError := errors.New("test error")
+
+	err := Chain().
+		Then(step(2)).
+		ThenNamed("fail", func() error {
+			Try(customError)
+
+			return nil
+		}).
+		Then(step(3)).
+		Run()
+
+	var stepErr *LazyErrorWithStep
+
+	// the step error already carries a caller, so it isn't wrapped again.
+	if !errors.Is(err, customError) || !errors.As(err, &stepErr) || err != stepErr || stepErr.Index != 1 || stepErr.Name != "fail" {
+		t.Fatal("unexpected:", err)
+	} else if len(steps) != 3 {
+		t.Fatal("unexpected:", steps)
+	} else {
+		fmt.Println(err)
+	}
+
+	if err := Chain().Then(testFuncPanic).Run(); !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+
+	if _, ok := Chain().Then(testFuncError).Run().(*LazyErrorWithCaller); !ok {
+		t.Fatal("unexpected: plain step error isn't wrapped")
+	}
+}
@@ -0,0 +1,129 @@
+package lazyerrors
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrTimeout - class of errors caused by an exceeded deadline or a timeout (e.g. of a network operation).
+	ErrTimeout = errors.New("timeout")
+	// ErrCanceled - class of errors caused by a canceled context.
+	ErrCanceled = errors.New("canceled")
+	// ErrRetryable - class of transient errors tagged with Retryable, worth retrying.
+	ErrRetryable = errors.New("retryable")
+	// ErrPermanent - class of errors tagged with Permanent, retrying won't help.
+	ErrPermanent = errors.New("permanent")
+	// ErrInvalid - class of errors tagged with Invalid, caused by invalid input rather than a failure.
+	ErrInvalid = errors.New("invalid")
+)
+
+// LazyErrorWithClass - custom error structure that tags the wrapped error with a class, such as ErrTimeout.
+type LazyErrorWithClass struct {
+	Err   error
+	Class error
+}
+
+// Error - error interface implementation, the class isn't added to the message.
+func (e *LazyErrorWithClass) Error() string {
+	return redact(e.Err.Error())
+}
+
+// Unwrap - error interface implementation.
+func (e *LazyErrorWithClass) Unwrap() error {
+	return e.Err
+}
+
+// Is - error interface implementation, err matches the class as well as the wrapped error.
+func (e *LazyErrorWithClass) Is(err error) bool {
+	return err == e.Class || errors.Is(e.Err, err)
+}
+
+// Message - returns text of the wrapped error.
+func (e *LazyErrorWithClass) Message() string {
+	return Message(e.Err)
+}
+
+// Classify - tags non-nil error err with its class (ErrTimeout or ErrCanceled) if it's detected by Class,
+// else returns err as is.
+func Classify(err error) error {
+	class := Class(err)
+	if class == nil || errors.Is(err, class) {
+		return err
+	}
+
+	return withClass(err, class)
+}
+
+// WithClass - tags non-nil error err with class, so it matches class via errors.Is (e.g. ErrTimeout or io.EOF).
+func WithClass(err, class error) error {
+	if err == nil {
+		return nil
+	}
+
+	return withClass(err, class)
+}
+
+// Retryable - tags non-nil error err with ErrRetryable.
+func Retryable(err error) error {
+	return WithClass(err, ErrRetryable)
+}
+
+// Permanent - tags non-nil error err with ErrPermanent.
+func Permanent(err error) error {
+	return WithClass(err, ErrPermanent)
+}
+
+// Invalid - tags non-nil error err with ErrInvalid.
+func Invalid(err error) error {
+	return WithClass(err, ErrInvalid)
+}
+
+// IsRetryable - reports whether error err is tagged with Retryable or classified as ErrTimeout
+// and isn't tagged with Permanent.
+func IsRetryable(err error) bool {
+	return !errors.Is(err, ErrPermanent) && (errors.Is(err, ErrRetryable) || IsTimeout(err))
+}
+
+// Class - returns class of error err: ErrPermanent, ErrRetryable or ErrInvalid if it's tagged so, ErrTimeout for exceeded
+// deadlines and timeouts, ErrCanceled for canceled contexts, nil otherwise.
+func Class(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrPermanent):
+		return ErrPermanent
+	case errors.Is(err, ErrRetryable):
+		return ErrRetryable
+	case errors.Is(err, ErrInvalid):
+		return ErrInvalid
+	case IsTimeout(err):
+		return ErrTimeout
+	case errors.Is(err, ErrCanceled), errors.Is(err, context.Canceled):
+		return ErrCanceled
+	default:
+		return nil
+	}
+}
+
+// IsTimeout - reports whether error err is classified as ErrTimeout, is context.DeadlineExceeded or an error
+// reporting a timeout (such as net.Error).
+func IsTimeout(err error) bool {
+	if errors.Is(err, ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var timeout interface{ Timeout() bool }
+
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
+
+// withClass - tags error err with class with annotate.
+func withClass(err, class error) error {
+	return annotate(err, func(err error) error {
+		return &LazyErrorWithClass{
+			Err:   err,
+			Class: class,
+		}
+	})
+}
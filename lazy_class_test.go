@@ -0,0 +1,67 @@
+package lazyerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestClassify(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+
+	<-ctx.Done()
+
+	var netErr error = &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{IsTimeout: true}}
+
+	for _, testCase := range []struct {
+		err   error
+		class error
+	}{
+		{nil, nil},
+		{io.EOF, nil},
+		{context.DeadlineExceeded, ErrTimeout},
+		{fmt.Errorf("query: %w", ctx.Err()), ErrTimeout},
+		{netErr, ErrTimeout},
+		{context.Canceled, ErrCanceled},
+	} {
+		if class := Class(testCase.err); class != testCase.class {
+			t.Fatal("unexpected:", testCase.err, class)
+		}
+
+		err := Classify(testCase.err)
+		if testCase.class != nil && (!errors.Is(err, testCase.class) || err.Error() != testCase.err.Error()) {
+			t.Fatal("unexpected:", err)
+		}
+	}
+
+	err := Do(func() { TryCtx(ctx, nil) })
+
+	var withCaller *LazyErrorWithCaller
+	if !errors.As(err, &withCaller) || !errors.Is(err, ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	if tagged := Classify(err); tagged != err {
+		t.Fatal("unexpected:", tagged)
+	}
+}
+
+func TestWithClass(t *testing.T) {
+	if err := WithClass(nil, ErrTimeout); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	err := Do(func() { Try(io.ErrUnexpectedEOF) })
+
+	tagged := WithClass(err, io.EOF)
+	if !errors.Is(tagged, io.EOF) || tagged.Error() != err.Error() || Class(tagged) != nil {
+		t.Fatal("unexpected:", tagged)
+	}
+}
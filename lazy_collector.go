@@ -0,0 +1,91 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrLimitReached - error thrown by Collector when the number of recorded errors reaches its limit.
+var ErrLimitReached = errors.New("error limit reached")
+
+// Collector - accumulator of errors for best-effort loops that process everything and report all failures.
+//
+//	var c lazyerrors.Collector
+//
+//	for _, item := range items {
+//	        c.TryContinue(process(item))
+//	}
+//
+//	return c.Err()
+//
+// A zero Collector is ready to use and safe for concurrent use.
+type Collector struct {
+	mu    sync.Mutex
+	errs  []error
+	limit int
+}
+
+// Limit - makes the collector throw with Try once n errors are recorded, n <= 0 removes the limit.
+//
+// The thrown error wraps ErrLimitReached only, recorded errors are returned by Err.
+func (c *Collector) Limit(n int) *Collector {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.limit = n
+
+	return c
+}
+
+// Collect - records non-nil error err as is, throws if the limit is reached.
+func (c *Collector) Collect(err error) {
+	if err == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.errs = append(c.errs, err)
+
+	var reached error
+	if c.limit > 0 && len(c.errs) >= c.limit {
+		reached = fmt.Errorf("%w (%d)", ErrLimitReached, c.limit)
+	}
+	c.mu.Unlock()
+
+	Try(reached)
+}
+
+// TryContinue - records non-nil error err wrapped like TryWrapErrorFunc does, but doesn't throw it unless
+// the limit is reached.
+func (c *Collector) TryContinue(err error) {
+	if err != nil {
+		c.Collect(wrapError(err))
+	}
+}
+
+// Err - returns errors.Join of all recorded errors, nil if there are none.
+func (c *Collector) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return errors.Join(c.errs...)
+}
+
+// Len - returns number of recorded errors.
+func (c *Collector) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.errs)
+}
+
+// wrapError - wraps error err into LazyErrorWithCaller unless it's already a lazy error.
+func wrapError(err error) error {
+	switch err.(type) {
+	case *LazyErrorFromPanic, *LazyErrorWithCaller, *LazyErrorWithStack:
+		return err
+	default:
+		return NewErrorWithCaller(err)
+	}
+}
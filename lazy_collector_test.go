@@ -0,0 +1,57 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCollector(t *testing.T) {
+	var c Collector
+
+	if c.Err() != nil {
+		t.Fatal("unexpected:", c.Err())
+	}
+
+	c.Collect(nil)
+	c.Collect(io.EOF)
+	c.TryContinue(nil)
+	c.TryContinue(testFuncError())
+	c.TryContinue(testWrapper(Try, Catch, testFuncPanic))
+
+	err := c.Err()
+	if c.Len() != 3 || !errors.Is(err, io.EOF) || !errors.Is(err, ErrPanic) || !strings.Contains(err.Error(), "lazy_collector_test.go") {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestCollectorLimit(t *testing.T) {
+	var (
+		c         = new(Collector).Limit(2)
+		processed int
+	)
+
+	err := Do(func() {
+		for i := 0; i < 5; i++ {
+			c.TryContinue(testFuncError())
+			processed++
+		}
+	})
+
+	if !errors.Is(err, ErrLimitReached) || processed != 1 || c.Len() != 2 || (callerEnabled && !strings.Contains(err.Error(), "lazy_collector_test.go")) {
+		t.Fatal("unexpected:", err, processed)
+	} else {
+		fmt.Println(err)
+	}
+
+	c.Limit(0)
+	c.Collect(io.EOF)
+
+	if c.Len() != 3 {
+		t.Fatal("unexpected:", c.Len())
+	}
+}
@@ -0,0 +1,165 @@
+package lazyerrors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+)
+
+// LazyErrorWithContext - custom error structure that contains values of a context snapshotted at throw time,
+// so they outlive the context itself.
+type LazyErrorWithContext struct {
+	Err    error
+	Values []slog.Attr
+}
+
+// Error - error interface implementation, context values aren't added to the message.
+func (e *LazyErrorWithContext) Error() string {
+	return redact(e.Err.Error())
+}
+
+// Unwrap - error interface implementation.
+func (e *LazyErrorWithContext) Unwrap() error {
+	return e.Err
+}
+
+// Is - error interface implementation.
+func (e *LazyErrorWithContext) Is(err error) bool {
+	return errors.Is(e.Err, err)
+}
+
+// Message - returns text of the wrapped error.
+func (e *LazyErrorWithContext) Message() string {
+	return Message(e.Err)
+}
+
+// MarshalJSON - json.Marshaler interface implementation.
+func (e *LazyErrorWithContext) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonError{
+		Message: Message(e.Err),
+		Attrs:   attrsMap(Attrs(e)),
+		Chain:   chain(e.Err),
+	})
+}
+
+// handlerKey - context key of Handler set with NewContext.
+type handlerKey struct{}
+
+// contextAttrs - extractors registered with RegisterContextAttr.
+var contextAttrs registry[func(ctx context.Context) (key string, value interface{})]
+
+// defaultHandler - Handler returned by FromContext if none was set, it uses Try and Catch defaults.
+var defaultHandler = &Handler{try: Try, catch: catchDefault}
+
+// NewContext - returns a copy of ctx carrying handler h, so request-scoped policies flow through call trees.
+func NewContext(ctx context.Context, h *Handler) context.Context {
+	return context.WithValue(ctx, handlerKey{}, h)
+}
+
+// FromContext - returns Handler carried by ctx, or Handler using defaults of Try and Catch if there's none.
+func FromContext(ctx context.Context) *Handler {
+	if ctx != nil {
+		if h, ok := ctx.Value(handlerKey{}).(*Handler); ok && h != nil {
+			return h
+		}
+	}
+
+	return defaultHandler
+}
+
+// RegisterContextAttr - registers extractor fn of an attribute (e.g. trace or request ID) attached by TryC and CatchC.
+//
+// Extractor returns empty key if ctx doesn't carry the value.
+func RegisterContextAttr(fn func(ctx context.Context) (key string, value interface{})) {
+	contextAttrs.add(fn)
+}
+
+// ResetContextAttrs - removes all extractors registered with RegisterContextAttr.
+func ResetContextAttrs() {
+	contextAttrs.reset()
+}
+
+// WithContext - snapshots values of ctx extracted by RegisterContextAttr into non-nil error err,
+// keys already attached to err are skipped.
+func WithContext(ctx context.Context, err error) error {
+	extractors := contextAttrs.load()
+	if err == nil || ctx == nil || len(extractors) == 0 {
+		return err
+	}
+
+	var (
+		values []slog.Attr
+		attrs  = Attrs(err)
+	)
+
+	for _, fn := range extractors {
+		if key, value := fn(ctx); key != "" && !hasAttr(attrs, key) && !hasAttr(values, key) {
+			values = append(values, slog.Any(key, value))
+		}
+	}
+
+	if len(values) == 0 {
+		return err
+	}
+
+	return withValues(err, values)
+}
+
+// ContextValues - returns context values snapshotted into error err and errors wrapped by it
+// (branches of joined errors included), outermost first.
+func ContextValues(err error) []slog.Attr {
+	var values []slog.Attr
+
+	walk(err, func(err error) bool {
+		if e, ok := err.(*LazyErrorWithContext); ok {
+			values = append(values, e.Values...)
+		}
+
+		return true
+	})
+
+	return values
+}
+
+// TryC - checks error err with the try handler of Handler carried by ctx, values of ctx are snapshotted with
+// WithContext.
+func TryC(ctx context.Context, err error) {
+	if err != nil {
+		FromContext(ctx).Try(WithContext(ctx, err))
+	}
+}
+
+// CatchC - catches thrown error or panic with the catch handler of Handler carried by ctx,
+// values of ctx are snapshotted into the caught error with WithContext.
+func CatchC(ctx context.Context, ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		FromContext(ctx).catch(ep, r)
+		*ep = WithContext(ctx, *ep)
+	}
+}
+
+// withValues - wraps error err with context values with annotate.
+func withValues(err error, values []slog.Attr) error {
+	return annotate(err, func(err error) error {
+		return &LazyErrorWithContext{
+			Err:    err,
+			Values: values,
+		}
+	})
+}
+
+// hasAttr - reports whether attributes contain one with key.
+func hasAttr(attrs []slog.Attr, key string) bool {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,152 @@
+package lazyerrors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestContextHandler(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		verbose = NewContext(ctx, New(WithTry(TryWrapStackFunc)))
+		plain   = NewContext(ctx, New(WithTry(TryErrorFunc), WithCatch(CatchAllHandler)))
+	)
+
+	if FromContext(ctx) != defaultHandler || FromContext(nil) != defaultHandler {
+		t.Fatal("unexpected: non-default handler")
+	}
+
+	run := func(ctx context.Context, f func() error) (err error) {
+		defer CatchC(ctx, &err)
+		TryC(ctx, f())
+
+		return
+	}
+
+	var withStack *LazyErrorWithStack
+
+	if err := run(verbose, testFuncError); !errors.As(err, &withStack) {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := run(plain, testFuncError); err == nil || err.Error() != "test error" {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := run(plain, testFuncPanic); err == nil || err.Error() != "panic: test panic" {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := run(ctx, testFuncPanic); !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(Message(err))
+	}
+}
+
+func TestContextAttr(t *testing.T) {
+	defer ResetContextAttrs()
+
+	type requestKey struct{}
+
+	RegisterContextAttr(func(ctx context.Context) (string, interface{}) {
+		if id, ok := ctx.Value(requestKey{}).(string); ok {
+			return "request_id", id
+		}
+
+		return "", nil
+	})
+
+	ctx := context.WithValue(context.Background(), requestKey{}, "req-42")
+
+	run := func(ctx context.Context, f func() error) (err error) {
+		defer CatchC(ctx, &err)
+		TryC(ctx, f())
+
+		return
+	}
+
+	for _, f := range []func() error{testFuncError, testFuncPanic} {
+		attrs := Attrs(run(ctx, f))
+		if len(attrs) != 1 || attrs[0].Key != "request_id" || attrs[0].Value.String() != "req-42" {
+			t.Fatal("unexpected:", attrs)
+		} else {
+			fmt.Println(attrs)
+		}
+	}
+
+	if attrs := Attrs(run(context.Background(), testFuncError)); len(attrs) != 0 {
+		t.Fatal("unexpected:", attrs)
+	}
+
+	if err := run(ctx, testFuncNoError); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	defer ResetContextAttrs()
+
+	type userKey struct{}
+
+	RegisterContextAttr(func(ctx context.Context) (string, interface{}) {
+		if id, ok := ctx.Value(userKey{}).(int); ok {
+			return "user_id", id
+		}
+
+		return "", nil
+	})
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), userKey{}, 7))
+
+	err := Do(func() { TryC(ctx, testFuncError()) })
+	cancel()
+
+	var withCaller *LazyErrorWithCaller
+	if !errors.As(err, &withCaller) || Message(err) != Message(testFuncError()) {
+		t.Fatal("unexpected:", err)
+	}
+
+	values := ContextValues(err)
+	if len(values) != 1 || values[0].Key != "user_id" || values[0].Value.Int64() != 7 {
+		t.Fatal("unexpected:", values)
+	}
+
+	if values := ContextValues(WithContext(ctx, err)); len(values) != 1 {
+		t.Fatal("unexpected:", values)
+	}
+
+	if data, jsonErr := json.Marshal(errors.Unwrap(err)); jsonErr != nil || !strings.Contains(string(data), `"user_id":7`) {
+		t.Fatal("unexpected:", string(data), jsonErr)
+	} else {
+		fmt.Println(string(data))
+	}
+
+	if err := WithContext(ctx, nil); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestResetContextAttrs(t *testing.T) {
+	defer ResetContextAttrs()
+
+	RegisterContextAttr(func(context.Context) (string, interface{}) { return "tenant", "test" })
+
+	// hooks and extractors are reset separately.
+	ResetHooks()
+
+	if attrs := Attrs(WithContext(context.Background(), io.EOF)); len(attrs) != 1 {
+		t.Fatal("unexpected:", attrs)
+	}
+
+	ResetContextAttrs()
+
+	if err := WithContext(context.Background(), io.EOF); err != io.EOF {
+		t.Fatal("unexpected:", err)
+	}
+}
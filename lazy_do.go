@@ -0,0 +1,17 @@
+package lazyerrors
+
+// Do - runs fn under Catch and returns the caught error.
+func Do(fn func()) (err error) {
+	defer Catch(&err)
+	fn()
+
+	return
+}
+
+// Do1 - runs fn under Catch and returns its value and the caught error.
+func Do1[T any](fn func() T) (v T, err error) {
+	defer Catch(&err)
+	v = fn()
+
+	return
+}
@@ -0,0 +1,36 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestDo(t *testing.T) {
+	if err := Do(func() { Try(testFuncNoError()) }); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := Do(func() { Try(testFuncError()) }); err == nil {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	if err := Do(func() { Try(testFuncPanic()) }); !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestDo1(t *testing.T) {
+	if v, err := Do1(func() int { return Try1(strconv.Atoi("42")) }); err != nil || v != 42 {
+		t.Fatal("unexpected:", v, err)
+	}
+
+	if v, err := Do1(func() int { return Try1(strconv.Atoi("test")) }); err == nil || v != 0 {
+		t.Fatal("unexpected:", v, err)
+	} else {
+		fmt.Println(err)
+	}
+}
@@ -35,54 +35,77 @@
 //   - On nil error execution will procede normally.
 //   - On non-nil error it will be wrapped to show the caller and risen as panic until Catch.
 //   - If an error was already wrapped, it won't be wrapped again to preserve the caller.
-//   - Wrapping can be disabled by assigning Try with another handler from a given set.
+//   - Wrapping can be disabled by setting another handler from a given set with SetDefaultTry.
 //
 // Now about Catch:
 //
 //   - By default Catch can recover from any error or panic.
-//   - Default behaviour can be changed by assigning Catch with another handler from a given set.
+//   - Default behaviour can be changed by setting another handler from a given set with SetDefaultCatch.
 //   - If Catch recovers from a panic, it wraps recovered information into LazyErrorFromPanic.
 //
 // Defaults:
 //
-//   - Try is set to TryWrapErrorFunc by default (wraps errors into LazyErrorWithCaller).
-//   - Catch is set to CatchAllWithStackFunc by default (wraps panics into LazyErrorFromPanic).
-//   - Fastest configuration with panic recover option would be TryErrorFunc/CatchAllFunc.
-//   - Fastest configuration without panic recover option would be TryErrorFunc/CatchErrorFunc.
+//   - Try uses TryWrapErrorFunc by default (wraps errors into LazyErrorWithCaller).
+//   - TryWrapStackFunc can be used instead to capture the full call stack (wraps errors into LazyErrorWithStack).
+//   - Catch uses CatchAllWithStackHandler by default (wraps panics into LazyErrorFromPanic).
+//   - Fastest configuration with panic recover option would be TryErrorFunc/CatchAllHandler.
+//   - Fastest configuration without panic recover option would be TryErrorFunc/CatchErrorHandler.
+//   - Defaults are safe to change concurrently, but they are meant to be set by initialization code.
+//   - Caller capture can be disabled with SetCaptureCaller or compiled out with lazyerrors_nocaller build tag.
 package lazyerrors
 
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"runtime"
-	"runtime/debug"
+	"strings"
+	"sync/atomic"
 )
 
 var (
-	// Catch - common catch handler, defaults to CatchAllWithStackFunc.
-	Catch = CatchAllWithStackFunc
-	// Try - common try handler, defaults to TryWrapErrorFunc.
-	Try = TryWrapErrorFunc
+	// defaultTry - handler used by Try, TryWrapErrorFunc if nil.
+	defaultTry atomic.Pointer[func(err error)]
+	// defaultCatch - handler used by Catch, CatchAllWithStackHandler if nil.
+	defaultCatch atomic.Pointer[CatchHandler]
 	// ErrPanic - default error wrapped inside of LazyErrorFromPanic for Uwrap consistency.
 	ErrPanic = errors.New("panic")
+	// skipCaller - disables caller capture, set with SetCaptureCaller.
+	skipCaller atomic.Bool
+	// packagePrefix - function name prefix of this package, used to skip own frames.
+	packagePrefix = reflect.TypeOf(LazyErrorWithCaller{}).PkgPath() + "."
 )
 
 type (
+	// CatchHandler - handler of a value recovered by Catch, it's never called with nil.
+	CatchHandler func(ep *error, recovered interface{})
 	// LazyErrorWithCaller - custom error structure that contains caller information.
 	LazyErrorWithCaller struct {
 		Err    error
 		Caller string
+		frame  Frame
+		hops   []Frame
 	}
 	// LazyErrorFromPanic - custom error structure that contains recover information and stack trace.
 	LazyErrorFromPanic struct {
-		Recovered interface{}
-		Stack     string
+		Recovered   interface{}
+		Stack       string
+		Kind        PanicKind
+		GoroutineID uint64
+		Labels      map[string]string
+		callers     []uintptr
+		hops        []Frame
+		err         error
 	}
 )
 
-// Error - error interface implementation.
+// Error - error interface implementation, the formatter set with SetCallerFormatter is used if any.
 func (e *LazyErrorWithCaller) Error() string {
-	return e.Caller + e.Err.Error()
+	if fn := callerFormatter.Load(); fn != nil {
+		return redact((*fn)(e))
+	}
+
+	return redact(e.Caller + truncateMessage(e.Err.Error()))
 }
 
 // Unwrap - error interface implementation.
@@ -95,13 +118,39 @@ func (e *LazyErrorWithCaller) Is(err error) bool {
 	return errors.Is(e.Err, err)
 }
 
-// Error - error interface implementation.
+// Frames - returns the caller as a single structured frame, nil if the caller is unknown.
+func (e *LazyErrorWithCaller) Frames() []Frame {
+	if e.frame == (Frame{}) {
+		return nil
+	}
+
+	return []Frame{e.frame}
+}
+
+// Error - error interface implementation, the report is flattened into a single line if SetSingleLine enabled it.
 func (e *LazyErrorFromPanic) Error() string {
-	return fmt.Sprintf("[%v recovered]:\n%v\n[stack]:\n%s", ErrPanic, e.Recovered, e.Stack)
+	if singleLine.Load() {
+		return redact(e.singleLine())
+	}
+
+	return e.Report()
+}
+
+// Report - returns the full multi-line report regardless of SetSingleLine.
+func (e *LazyErrorFromPanic) Report() string {
+	if e.GoroutineID != 0 || len(e.Labels) > 0 {
+		return redact(fmt.Sprintf("[%s]:\n%s\n[goroutine]:\n%s\n[stack]:\n%s", e.headline(), e.recovered(), e.goroutine(), e.truncatedStack()))
+	}
+
+	return redact(fmt.Sprintf("[%s]:\n%s\n[stack]:\n%s", e.headline(), e.recovered(), e.truncatedStack()))
 }
 
 // Unwrap - error interface implementation.
 func (e *LazyErrorFromPanic) Unwrap() error {
+	if e.err != nil {
+		return e.err
+	}
+
 	return ErrPanic
 }
 
@@ -110,11 +159,34 @@ func (e *LazyErrorFromPanic) Is(err error) bool {
 	return errors.Is(ErrPanic, err)
 }
 
+// As - errors.As support, targets are matched against the recovered value if it's an error (e.g. runtime.Error).
+func (e *LazyErrorFromPanic) As(target interface{}) bool {
+	if err, ok := e.Recovered.(error); ok {
+		return errors.As(err, target)
+	}
+
+	return false
+}
+
+// Frames - returns structured frames of the stack captured at recover time, nil if it wasn't captured.
+func (e *LazyErrorFromPanic) Frames() []Frame {
+	return resolveFrames(e.callers)
+}
+
 // NewErrorWithCaller - adds caller information to error err and wraps it into LazyErrorWithCaller.
+//
+// Caller isn't captured if it's disabled with SetCaptureCaller.
 func NewErrorWithCaller(err error) error {
+	if !CapturesCaller() {
+		return &LazyErrorWithCaller{Err: err}
+	}
+
+	frame := caller()
+
 	return &LazyErrorWithCaller{
 		Err:    err,
-		Caller: caller(),
+		Caller: frame.caller(),
+		frame:  frame,
 	}
 }
 
@@ -126,25 +198,76 @@ func NewErrorFromPanic(recovered interface{}, stack []byte) error {
 	}
 }
 
-// caller - returns a caller for ErrorWithCaller.
-func caller() string {
-	if _, file, line, ok := runtime.Caller(3); ok {
-		return fmt.Sprintf("%s:%d: ", file, line)
+// StackTrace - returns raw (unfiltered) program counters of the stack captured at recover time.
+func (e *LazyErrorFromPanic) StackTrace() StackTrace {
+	return newStackTrace(e.callers)
+}
+
+// stack - returns Stack if it was given, else formats the stack captured at recover time.
+func (e *LazyErrorFromPanic) stack() string {
+	if e.Stack != "" {
+		return e.Stack
 	}
 
-	return ""
+	return formatFrames(e.Frames())
+}
+
+// newErrorFromPanic - wraps recovered information into LazyErrorFromPanic, capturing the current stack.
+//
+// Only program counters are captured, the stack is resolved when the error is formatted.
+func newErrorFromPanic(recovered interface{}) error {
+	recordStorm()
+
+	e := &LazyErrorFromPanic{
+		Recovered: recovered,
+		Kind:      classifyPanic(recovered),
+		callers:   callers(),
+	}
+
+	if recordGoroutine.Load() {
+		e.GoroutineID = goroutineID()
+	}
+
+	return e
+}
+
+// caller - returns the first caller outside of this package for ErrorWithCaller.
+func caller() Frame {
+	var pcs [32]uintptr
+
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+
+	for {
+		frame, more := frames.Next()
+		if f := newFrame(frame); !isPackageFrame(f) {
+			return f
+		}
+
+		if !more {
+			return Frame{}
+		}
+	}
+}
+
+// isPackageFrame - reports whether frame belongs to this package (tests are not counted).
+func isPackageFrame(frame Frame) bool {
+	return strings.HasPrefix(frame.Function, packagePrefix) && !strings.HasSuffix(frame.File, "_test.go")
 }
 
 // TryWrapErrorFunc - wraps non-nil error err into LazyErrorWithCaller and throws it as a panic.
 func TryWrapErrorFunc(err error) {
 	if err != nil {
 		switch err.(type) {
-		// if an error is already wrapped, then return it as is.
-		case *LazyErrorFromPanic, *LazyErrorWithCaller:
-			panic(err)
+		// if an error is already wrapped, then return it as is (recording a hop if enabled).
+		case *LazyErrorFromPanic, *LazyErrorWithCaller, *LazyErrorWithStack:
+			if recordHops.Load() {
+				err = withHop(err)
+			}
+
+			throw(err)
 		// else - wrap it into ErrorWithCaller.
 		default:
-			panic(NewErrorWithCaller(err))
+			throw(NewErrorWithCaller(err))
 		}
 	}
 }
@@ -152,10 +275,78 @@ func TryWrapErrorFunc(err error) {
 // TryErrorFunc - throws non-nil error err as a panic.
 func TryErrorFunc(err error) {
 	if err != nil {
-		panic(err)
+		throw(err)
+	}
+}
+
+// Try - common try handler, uses TryWrapErrorFunc unless another one is set with SetDefaultTry.
+func Try(err error) {
+	if fn := defaultTry.Load(); fn != nil {
+		(*fn)(err)
+
+		return
+	}
+
+	TryWrapErrorFunc(err)
+}
+
+// Catch - common catch handler, uses CatchAllWithStackHandler unless another one is set with SetDefaultCatch.
+func Catch(ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		catchDefault(ep, r)
 	}
 }
 
+// catchDefault - CatchHandler of Catch, dispatches recovered r to the handler set with SetDefaultCatch.
+func catchDefault(ep *error, r interface{}) {
+	if h := defaultCatch.Load(); h != nil {
+		(*h)(ep, r)
+
+		return
+	}
+
+	CatchAllWithStackHandler(ep, r)
+}
+
+// SetDefaultTry - sets handler fn used by Try, nil restores TryWrapErrorFunc.
+func SetDefaultTry(fn func(err error)) {
+	if fn == nil {
+		defaultTry.Store(nil)
+
+		return
+	}
+
+	defaultTry.Store(&fn)
+}
+
+// SetDefaultCatch - sets handler h used by Catch, nil restores CatchAllWithStackHandler.
+//
+// Unlike catch functions, h is given the recovered value, as recover works only when called by Catch itself.
+func SetDefaultCatch(h CatchHandler) {
+	if h == nil {
+		defaultCatch.Store(nil)
+
+		return
+	}
+
+	defaultCatch.Store(&h)
+}
+
+// SetCaptureCaller - enables or disables caller capture, it's enabled by default and has no effect
+// with lazyerrors_nocaller build tag.
+func SetCaptureCaller(on bool) {
+	skipCaller.Store(!on)
+}
+
+// CapturesCaller - reports whether callers are captured.
+func CapturesCaller() bool {
+	return callerEnabled && !skipCaller.Load()
+}
+
 // CatchLazyErrorFunc - catches only lazy errors.
 func CatchLazyErrorFunc(ep *error) {
 	if ep == nil {
@@ -163,18 +354,27 @@ func CatchLazyErrorFunc(ep *error) {
 	}
 	// recover from panic.
 	if r := recover(); r != nil {
-		// panic upon everything execept for LazyErrorFromPanic and LazyErrorWithCaller.
-		switch t := r.(type) {
-		case *LazyErrorFromPanic:
-			*ep = t
-		case *LazyErrorWithCaller:
-			*ep = t
-		default:
-			panic(r)
-		}
+		CatchLazyErrorHandler(ep, r)
 	}
 }
 
+// CatchLazyErrorHandler - CatchHandler of CatchLazyErrorFunc.
+func CatchLazyErrorHandler(ep *error, r interface{}) {
+	// panic upon everything execept for lazy errors.
+	switch t := r.(type) {
+	case *LazyErrorFromPanic:
+		*ep = t
+	case *LazyErrorWithCaller:
+		*ep = t
+	case *LazyErrorWithStack:
+		*ep = t
+	default:
+		panic(r)
+	}
+
+	notifyCatch(*ep, false)
+}
+
 // CatchErrorFunc - catches thrown error.
 func CatchErrorFunc(ep *error) {
 	if ep == nil {
@@ -182,15 +382,21 @@ func CatchErrorFunc(ep *error) {
 	}
 	// recover from panic.
 	if r := recover(); r != nil {
-		// if an error was thrown, assign it through the pointer and return.
-		if err, ok := r.(error); ok {
-			*ep = err
+		CatchErrorHandler(ep, r)
+	}
+}
 
-			return
-		}
-		// else continue panicking.
-		panic(r)
+// CatchErrorHandler - CatchHandler of CatchErrorFunc.
+func CatchErrorHandler(ep *error, r interface{}) {
+	// if an error was thrown, assign it through the pointer and return.
+	if err, ok := r.(error); ok {
+		*ep = err
+		notifyCatch(err, isPanic(r))
+
+		return
 	}
+	// else continue panicking.
+	panic(r)
 }
 
 // CatchAllWithStackFunc - catches thrown error or panic (stack will be added).
@@ -200,15 +406,22 @@ func CatchAllWithStackFunc(ep *error) {
 	}
 	// recover from panic.
 	if r := recover(); r != nil {
-		// if an error was thrown, assign it through the pointer and return.
-		if err, ok := r.(error); ok {
-			*ep = err
+		CatchAllWithStackHandler(ep, r)
+	}
+}
 
-			return
-		}
-		// else wrap a panic info into LazyErrorFromPanic, stack included.
-		*ep = NewErrorFromPanic(r, debug.Stack())
+// CatchAllWithStackHandler - CatchHandler of CatchAllWithStackFunc.
+func CatchAllWithStackHandler(ep *error, r interface{}) {
+	// if an error was thrown, assign it through the pointer and return.
+	if err, ok := r.(error); ok && !isRuntimeError(err) {
+		*ep = err
+		notifyCatch(err, false)
+
+		return
 	}
+	// else wrap a panic info (runtime errors included) into LazyErrorFromPanic, stack included.
+	*ep = newErrorFromPanic(r)
+	notifyCatch(*ep, true)
 }
 
 // CatchAllFunc - catches thrown error or panic (stack won't be added).
@@ -218,13 +431,20 @@ func CatchAllFunc(ep *error) {
 	}
 	// recover from panic.
 	if r := recover(); r != nil {
-		// if an error was thrown, assign it through the pointer and return.
-		if err, ok := r.(error); ok {
-			*ep = err
+		CatchAllHandler(ep, r)
+	}
+}
 
-			return
-		}
-		// else wrap a panic info into an error.
-		*ep = fmt.Errorf("panic: %v", r)
+// CatchAllHandler - CatchHandler of CatchAllFunc.
+func CatchAllHandler(ep *error, r interface{}) {
+	// if an error was thrown, assign it through the pointer and return.
+	if err, ok := r.(error); ok {
+		*ep = err
+		notifyCatch(err, isPanic(r))
+
+		return
 	}
+	// else wrap a panic info into an error.
+	*ep = fmt.Errorf("panic: %v", r)
+	notifyCatch(*ep, true)
 }
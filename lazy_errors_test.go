@@ -3,6 +3,7 @@ package lazyerrors
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 )
 
@@ -213,3 +214,69 @@ func testFuncNoError() error {
 func testFuncError() error {
 	return errors.New("test error")
 }
+
+func TestCaptureCaller(t *testing.T) {
+	defer SetCaptureCaller(true)
+
+	SetCaptureCaller(false)
+
+	if err := testWrapper(TryWrapErrorFunc, CatchAllFunc, testFuncError); err.Error() != "test error" {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	defer SetDefaultTry(nil)
+	defer SetDefaultCatch(nil)
+
+	SetDefaultTry(TryErrorFunc)
+	SetDefaultCatch(CatchAllHandler)
+
+	if err := testWrapper(Try, Catch, testFuncError); err == nil || err.Error() != "test error" {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := testWrapper(Try, Catch, testFuncPanic); err == nil || err.Error() != "panic: test panic" {
+		t.Fatal("unexpected:", err)
+	}
+
+	SetDefaultTry(nil)
+	SetDefaultCatch(nil)
+
+	if err := testWrapper(Try, Catch, testFuncPanic); !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+
+	// defaults can be changed while Try and Catch are in use.
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				if err := testWrapper(Try, Catch, testFuncError); err == nil {
+					t.Error("unexpected: nil error")
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		SetDefaultTry(TryWrapStackFunc)
+		SetDefaultCatch(CatchAllWithStackHandler)
+	}
+
+	wg.Wait()
+}
+
+// skipWithoutCaller - skips test t if caller capture is compiled out by lazyerrors_nocaller tag.
+func skipWithoutCaller(t *testing.T) {
+	if !callerEnabled {
+		t.Skip("caller capture is compiled out")
+	}
+}
@@ -0,0 +1,111 @@
+package lazyerrors
+
+import (
+	"expvar"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// expvarTopFingerprints - number of the most frequent fingerprints published by PublishExpvar.
+	expvarTopFingerprints = 10
+	// expvarMaxFingerprints - maximum number of distinct fingerprints counted by PublishExpvar.
+	expvarMaxFingerprints = 1000
+)
+
+type (
+	// expvarStats - counters of errors with the same fingerprint published by PublishExpvar.
+	expvarStats struct {
+		mu     sync.Mutex
+		counts map[string]*expvarFingerprint
+	}
+	// expvarFingerprint - published number of errors with the same fingerprint.
+	expvarFingerprint struct {
+		Fingerprint string `json:"fingerprint"`
+		Message     string `json:"message"`
+		Count       uint64 `json:"count"`
+	}
+)
+
+// PublishExpvar - publishes error statistics as expvar.Map named prefix and registers OnCatch hook updating it.
+//
+// The map contains errors_caught, panics_recovered, last_panic_time and top_fingerprints,
+// "lazyerrors" is used if prefix is empty. Like expvar.Publish, it panics if the name is already registered.
+func PublishExpvar(prefix string) *expvar.Map {
+	if prefix == "" {
+		prefix = "lazyerrors"
+	}
+
+	var (
+		m      = new(expvar.Map).Init()
+		caught = new(expvar.Int)
+		panics = new(expvar.Int)
+		last   = new(expvar.String)
+		stats  = &expvarStats{counts: make(map[string]*expvarFingerprint)}
+	)
+
+	m.Set("errors_caught", caught)
+	m.Set("panics_recovered", panics)
+	m.Set("last_panic_time", last)
+	m.Set("top_fingerprints", expvar.Func(stats.top))
+	expvar.Publish(prefix, m)
+
+	RegisterOnCatch(func(err error, recovered bool) {
+		caught.Add(1)
+
+		if recovered {
+			panics.Add(1)
+			last.Set(time.Now().UTC().Format(time.RFC3339Nano))
+		}
+
+		stats.add(err)
+	})
+
+	return m
+}
+
+// add - counts an occurrence of error err, new fingerprints are ignored once expvarMaxFingerprints is reached.
+func (s *expvarStats) add(err error) {
+	key := Fingerprint(err)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counts[key]
+	if !ok {
+		if len(s.counts) >= expvarMaxFingerprints {
+			return
+		}
+
+		c = &expvarFingerprint{Fingerprint: key, Message: Message(err)}
+		s.counts[key] = c
+	}
+
+	c.Count++
+}
+
+// top - returns the most frequent fingerprints, ties are sorted by fingerprint.
+func (s *expvarStats) top() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	top := make([]expvarFingerprint, 0, len(s.counts))
+	for _, c := range s.counts {
+		top = append(top, *c)
+	}
+
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+
+		return top[i].Fingerprint < top[j].Fingerprint
+	})
+
+	if len(top) > expvarTopFingerprints {
+		top = top[:expvarTopFingerprints]
+	}
+
+	return top
+}
@@ -0,0 +1,40 @@
+package lazyerrors
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestPublishExpvar(t *testing.T) {
+	defer ResetHooks()
+
+	m := PublishExpvar(fmt.Sprintf("lazyerrors_test_%d", time.Now().UnixNano()))
+
+	for _, f := range []func() error{testFuncNoError, testFuncError, testFuncError, testFuncPanic} {
+		_ = testWrapper(Try, Catch, f)
+	}
+
+	var stats struct {
+		ErrorsCaught    int    `json:"errors_caught"`
+		PanicsRecovered int    `json:"panics_recovered"`
+		LastPanicTime   string `json:"last_panic_time"`
+	}
+
+	if err := json.Unmarshal([]byte(m.String()), &stats); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	if stats.ErrorsCaught != 3 || stats.PanicsRecovered != 1 || stats.LastPanicTime == "" {
+		t.Fatal("unexpected:", m)
+	}
+
+	var top []expvarFingerprint
+
+	if err := json.Unmarshal([]byte(m.Get("top_fingerprints").String()), &top); err != nil || len(top) != 2 || top[0].Count != 2 || top[0].Message != "test error" {
+		t.Fatal("unexpected:", top, err)
+	} else {
+		fmt.Println(m)
+	}
+}
@@ -0,0 +1,88 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// fingerprintFrames - maximum number of application frames hashed by Fingerprint.
+const fingerprintFrames = 3
+
+// Fingerprint - returns a short stable hash of error err: type and message template of the root error
+// and functions of the top application frames.
+//
+// Numbers in the message are replaced with "#", line numbers and standard library frames are skipped,
+// so errors from the same code path have the same fingerprint across occurrences and rebuilds.
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	h := fnv.New64a()
+
+	fmt.Fprintf(h, "%s\x00%s", ErrorType(err), messageTemplate(rootMessage(err)))
+
+	var (
+		f interface{ Frames() []Frame }
+		n int
+	)
+
+	if errors.As(err, &f) {
+		for _, frame := range f.Frames() {
+			if n == fingerprintFrames {
+				break
+			}
+
+			if !isStandardFrame(frame) {
+				fmt.Fprintf(h, "\x00%s", frame.Function)
+				n++
+			}
+		}
+	}
+
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// rootMessage - returns message of the innermost error wrapped by err, recovered value is used for panics.
+func rootMessage(err error) string {
+	var fromPanic *LazyErrorFromPanic
+	if errors.As(err, &fromPanic) {
+		return fromPanic.Message()
+	}
+
+	for next := errors.Unwrap(err); next != nil; next = errors.Unwrap(next) {
+		err = next
+	}
+
+	return Message(err)
+}
+
+// messageTemplate - replaces runs of digits in message msg with "#".
+func messageTemplate(msg string) string {
+	var (
+		b     strings.Builder
+		digit bool
+	)
+
+	b.Grow(len(msg))
+
+	for _, r := range msg {
+		if r >= '0' && r <= '9' {
+			if !digit {
+				b.WriteByte('#')
+			}
+
+			digit = true
+
+			continue
+		}
+
+		digit = false
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,31 @@
+package lazyerrors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	newErr := func(id int) error {
+		return testWrapper(Try, Catch, func() error { return fmt.Errorf("user %d not found", id) })
+	}
+
+	if Fingerprint(nil) != "" || Fingerprint(newErr(42)) != Fingerprint(newErr(43)) {
+		t.Fatal("unexpected:", Fingerprint(newErr(42)), Fingerprint(newErr(43)))
+	}
+
+	for _, err := range []error{
+		testWrapper(Try, Catch, testFuncError),
+		testWrapper(Try, Catch, testFuncPanic),
+	} {
+		if fp := Fingerprint(err); fp == Fingerprint(newErr(42)) || len(fp) != 16 {
+			t.Fatal("unexpected:", fp)
+		} else {
+			fmt.Println(fp, err)
+		}
+	}
+
+	if messageTemplate("port 8080, retry 3") != "port #, retry #" {
+		t.Fatal("unexpected:", messageTemplate("port 8080, retry 3"))
+	}
+}
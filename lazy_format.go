@@ -0,0 +1,42 @@
+package lazyerrors
+
+import (
+	"strings"
+	"sync/atomic"
+	"text/template"
+)
+
+// callerFormatter - formatter of LazyErrorWithCaller set with SetCallerFormatter.
+var callerFormatter atomic.Pointer[func(e *LazyErrorWithCaller) string]
+
+// SetCallerFormatter - sets optional formatter fn of LazyErrorWithCaller used by Error, "caller: message" form
+// is used if nil (default).
+func SetCallerFormatter(fn func(e *LazyErrorWithCaller) string) {
+	if fn == nil {
+		callerFormatter.Store(nil)
+
+		return
+	}
+
+	callerFormatter.Store(&fn)
+}
+
+// CallerTemplate - returns formatter for SetCallerFormatter that executes tmpl with the error as data.
+//
+//	lazyerrors.SetCallerFormatter(lazyerrors.CallerTemplate(template.Must(template.New("").Parse(`{{.Err}} at {{.Caller}}`))))
+//
+// Caller is trimmed of its ": " suffix, errors of template execution are rendered instead of the message.
+func CallerTemplate(tmpl *template.Template) func(e *LazyErrorWithCaller) string {
+	return func(e *LazyErrorWithCaller) string {
+		var b strings.Builder
+
+		data := *e
+		data.Caller = strings.TrimSuffix(e.Caller, ": ")
+
+		if err := tmpl.Execute(&b, &data); err != nil {
+			return err.Error()
+		}
+
+		return b.String()
+	}
+}
@@ -0,0 +1,30 @@
+package lazyerrors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestCallerFormatter(t *testing.T) {
+	defer SetCallerFormatter(nil)
+
+	SetCallerFormatter(func(e *LazyErrorWithCaller) string {
+		return e.Err.Error() + " | " + e.Caller
+	})
+
+	if err := testWrapper(TryWrapErrorFunc, CatchAllFunc, testFuncError); !strings.HasPrefix(err.Error(), "test error | ") {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	SetCallerFormatter(CallerTemplate(template.Must(template.New("").Parse(`[{{.Caller}}] {{.Err}}`))))
+
+	if err := testWrapper(TryWrapErrorFunc, CatchAllFunc, testFuncError); !strings.HasPrefix(err.Error(), "[") || !strings.HasSuffix(err.Error(), "] test error") {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
@@ -0,0 +1,46 @@
+package lazyerrors
+
+// Future - result of a function running asynchronously, see Async.
+type Future[T any] struct {
+	done chan struct{}
+	v    T
+	err  error
+}
+
+// Async - runs fn under Catch in a new goroutine and returns Future of its result.
+//
+//	func foo() (err error) {
+//	        defer lazyerrors.Catch(&err)
+//	        a, b := lazyerrors.Async(bar), lazyerrors.Async(baz)
+//	        use(a.Get(), b.Get())
+//
+//	        return
+//	}
+func Async[T any](fn func() (T, error)) *Future[T] {
+	f := &Future[T]{done: make(chan struct{})}
+
+	go func() {
+		defer close(f.done)
+
+		f.err = runSafe(func() error { f.v = Try1(fn()); return nil })
+	}()
+
+	return f
+}
+
+// Get - blocks until the function is finished, checks its error with Try and returns its value.
+func (f *Future[T]) Get() T {
+	return Try1(f.Wait())
+}
+
+// Wait - blocks until the function is finished and returns its value and error (a recovered panic included).
+func (f *Future[T]) Wait() (T, error) {
+	<-f.done
+
+	return f.v, f.err
+}
+
+// Done - returns a channel that is closed when the function is finished.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
@@ -0,0 +1,31 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestAsync(t *testing.T) {
+	var (
+		value = Async(func() (int, error) { return 42, nil })
+		fail  = Async(func() (int, error) { return 0, testFuncError() })
+		fatal = Async(func() (int, error) { return 0, testFuncPanic() })
+	)
+
+	if v, err := Do1(value.Get); v != 42 || err != nil {
+		t.Fatal("unexpected:", v, err)
+	}
+
+	if _, err := Do1(fail.Get); err == nil || errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+
+	<-fatal.Done()
+
+	if _, err := fatal.Wait(); !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
@@ -0,0 +1,51 @@
+package lazyerrors
+
+import "context"
+
+// Go - runs fn in a new goroutine under Catch, the caught error is sent to the returned channel, which is closed then.
+//
+// Nothing is sent if fn succeeds, so receiving from the channel yields nil. The caught error is wrapped
+// into LazyErrorWithOrigin with the stack of the Go caller.
+func Go(fn func()) <-chan error {
+	var (
+		ch     = make(chan error, 1)
+		origin = NewOrigin()
+	)
+
+	go func() {
+		if err := runSafe(func() error { fn(); return nil }); err != nil {
+			ch <- origin.Wrap(err)
+		}
+
+		close(ch)
+	}()
+
+	return ch
+}
+
+// GoCtx - runs fn with ctx in a new goroutine like Go, pprof labels of ctx are recorded into recovered panics.
+//
+// If ctx is already done, fn isn't run and the context error is sent instead.
+func GoCtx(ctx context.Context, fn func(ctx context.Context)) <-chan error {
+	var (
+		ch     = make(chan error, 1)
+		origin = NewOrigin()
+	)
+
+	go func() {
+		err := ctx.Err()
+		if err == nil {
+			err = runSafe(func() error { fn(ctx); return nil })
+		}
+
+		err = withLabels(ctx, err)
+
+		if err != nil {
+			ch <- origin.Wrap(err)
+		}
+
+		close(ch)
+	}()
+
+	return ch
+}
@@ -0,0 +1,76 @@
+package lazyerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/pprof"
+	"testing"
+)
+
+func TestGo(t *testing.T) {
+	if err := <-Go(func() { Try(testFuncNoError()) }); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := <-Go(func() { Try(testFuncError()) }); err == nil || errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+
+	err := <-Go(func() { Try(testFuncPanic()) })
+
+	var (
+		fromPanic  *LazyErrorFromPanic
+		withOrigin *LazyErrorWithOrigin
+	)
+
+	if !errors.As(err, &fromPanic) || !errors.As(err, &withOrigin) || withOrigin.Origin.Frames()[0].Function != packagePrefix+"TestGo" {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestGoCtx(t *testing.T) {
+	ctx := pprof.WithLabels(context.Background(), pprof.Labels("worker", "test"))
+
+	var fromPanic *LazyErrorFromPanic
+
+	if err := <-GoCtx(ctx, func(context.Context) { Try(testFuncPanic()) }); !errors.As(err, &fromPanic) || fromPanic.Labels["worker"] != "test" {
+		t.Fatal("unexpected:", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if err := <-GoCtx(ctx, func(context.Context) { t.Error("unexpected: fn was called") }); !errors.Is(err, context.Canceled) {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestGoCatchErrorHandler(t *testing.T) {
+	defer SetDefaultCatch(nil)
+
+	// panics aren't recovered by the default Catch, but spawned goroutines still don't crash.
+	SetDefaultCatch(CatchErrorHandler)
+
+	panicking := func() error { panic("test panic") }
+
+	for _, err := range []error{
+		<-Go(func() { _ = panicking() }),
+		<-GoCtx(context.Background(), func(context.Context) { _ = panicking() }),
+		WaitAll(panicking),
+		func() error { _, err := Async(func() (int, error) { return 0, panicking() }).Wait(); return err }(),
+	} {
+		if !errors.Is(err, ErrPanic) {
+			t.Fatal("unexpected:", err)
+		}
+	}
+
+	s := Scope(context.Background())
+	s.Go(func(context.Context) { _ = panicking() })
+
+	if err := s.Wait(); !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+}
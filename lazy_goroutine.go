@@ -0,0 +1,100 @@
+package lazyerrors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// recordGoroutine - records goroutine IDs of panics, set with SetRecordGoroutine.
+var recordGoroutine atomic.Bool
+
+// SetRecordGoroutine - enables or disables recording ID of the goroutine recovered from a panic into
+// LazyErrorFromPanic, it's disabled by default.
+func SetRecordGoroutine(on bool) {
+	recordGoroutine.Store(on)
+}
+
+// CatchWithLabels - catches thrown error or panic like CatchAllWithStackFunc and records pprof labels of ctx.
+//
+// Labels are recorded only into LazyErrorFromPanic, pprof labels can't be obtained without a context.
+func CatchWithLabels(ctx context.Context, ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		*ep = withLabels(ctx, recoveredError(r))
+	}
+}
+
+// withLabels - returns a copy of err with pprof labels of ctx recorded if it's LazyErrorFromPanic, err is returned
+// as is otherwise. The error itself isn't modified, since it can be rethrown concurrently.
+func withLabels(ctx context.Context, err error) error {
+	t, ok := err.(*LazyErrorFromPanic)
+	if !ok || ctx == nil {
+		return err
+	}
+
+	var e *LazyErrorFromPanic
+
+	pprof.ForLabels(ctx, func(key, value string) bool {
+		if e == nil {
+			copied := *t
+			copied.Labels = make(map[string]string, len(t.Labels)+1)
+
+			for k, v := range t.Labels {
+				copied.Labels[k] = v
+			}
+
+			e = &copied
+		}
+
+		e.Labels[key] = value
+
+		return true
+	})
+
+	if e == nil {
+		return err
+	}
+
+	return e
+}
+
+// goroutine - formats goroutine ID and labels of the panic.
+func (e *LazyErrorFromPanic) goroutine() string {
+	keys := make([]string, 0, len(e.Labels))
+	for key := range e.Labels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	labels := make([]string, 0, len(keys))
+	for _, key := range keys {
+		labels = append(labels, key+"="+e.Labels[key])
+	}
+
+	return fmt.Sprintf("%d {%s}", e.GoroutineID, strings.Join(labels, ", "))
+}
+
+// goroutineID - returns ID of the current goroutine parsed from its stack header, 0 if unknown.
+func goroutineID() uint64 {
+	var buf [64]byte
+
+	header := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	if i := bytes.IndexByte(header, ' '); i > 0 {
+		id, _ := strconv.ParseUint(string(header[:i]), 10, 64)
+
+		return id
+	}
+
+	return 0
+}
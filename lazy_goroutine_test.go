@@ -0,0 +1,70 @@
+package lazyerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/pprof"
+	"strings"
+	"testing"
+)
+
+func TestRecordGoroutine(t *testing.T) {
+	defer SetRecordGoroutine(false)
+
+	SetRecordGoroutine(true)
+
+	var panicErr *LazyErrorFromPanic
+
+	if err := testWrapper(Try, Catch, testFuncPanic); !errors.As(err, &panicErr) || panicErr.GoroutineID == 0 {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestCatchWithLabels(t *testing.T) {
+	ctx := pprof.WithLabels(context.Background(), pprof.Labels("request", "42", "handler", "test"))
+
+	var err error
+
+	pprof.Do(ctx, pprof.Labels(), func(ctx context.Context) {
+		defer CatchWithLabels(ctx, &err)
+		Try(testFuncPanic())
+	})
+
+	var panicErr *LazyErrorFromPanic
+
+	if !errors.As(err, &panicErr) || panicErr.Labels["request"] != "42" || !strings.Contains(err.Error(), "{handler=test, request=42}") {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestCatchWithLabelsShared(t *testing.T) {
+	shared := testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, testFuncPanic)
+	errs := make(chan error, 2)
+
+	// the same error is rethrown concurrently under different labels.
+	for _, worker := range []string{"a", "b"} {
+		go func(worker string) {
+			var err error
+
+			ctx := pprof.WithLabels(context.Background(), pprof.Labels("worker", worker))
+
+			func() {
+				defer CatchWithLabels(ctx, &err)
+				Rethrow(shared)
+			}()
+
+			errs <- err
+		}(worker)
+	}
+
+	first, second := (<-errs).(*LazyErrorFromPanic), (<-errs).(*LazyErrorFromPanic)
+
+	if shared.(*LazyErrorFromPanic).Labels != nil || first.Labels["worker"] == second.Labels["worker"] {
+		t.Fatal("unexpected:", shared, first.Labels, second.Labels)
+	}
+}
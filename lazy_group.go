@@ -0,0 +1,111 @@
+package lazyerrors
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Group - collection of goroutines working on subtasks like errgroup.Group of golang.org/x/sync,
+// but every task is run under Catch, so Try can be used inside of it and panics become errors.
+//
+// A zero Group is valid, has no limit on the number of active goroutines and doesn't cancel on error.
+type Group struct {
+	// JoinErrors - makes Wait return all errors joined with errors.Join instead of the first one.
+	JoinErrors bool
+
+	wg     sync.WaitGroup
+	sem    chan struct{}
+	cancel context.CancelCauseFunc
+	mu     sync.Mutex
+	errs   []error
+}
+
+// GroupWithContext - returns a new Group and derived context, which is canceled by the first failed task or Wait.
+func GroupWithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	return &Group{cancel: cancel}, ctx
+}
+
+// SetLimit - limits the number of active goroutines to n, negative n removes the limit.
+//
+// It must not be called while any goroutines of the group are active.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+
+		return
+	}
+
+	g.sem = make(chan struct{}, n)
+}
+
+// Go - runs fn under Catch in a new goroutine, blocks until it can be started if the limit is reached.
+func (g *Group) Go(fn func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.wg.Add(1)
+
+	go func() {
+		defer g.done()
+
+		if err := runSafe(fn); err != nil {
+			g.fail(err)
+		}
+	}()
+}
+
+// Wait - blocks until all tasks are finished and returns the first error, or all of them if JoinErrors is set.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+
+	if g.cancel != nil {
+		g.cancel(nil)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.errs) == 0 {
+		return nil
+	}
+
+	if g.JoinErrors {
+		return errors.Join(g.errs...)
+	}
+
+	return g.errs[0]
+}
+
+// done - marks a task finished and releases its slot.
+func (g *Group) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+
+	g.wg.Done()
+}
+
+// fail - records error err of a task, the context is canceled on the first one.
+func (g *Group) fail(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.errs) == 0 && g.cancel != nil {
+		g.cancel(err)
+	}
+
+	if len(g.errs) == 0 || g.JoinErrors {
+		g.errs = append(g.errs, err)
+	}
+}
+
+// run - runs fn under Catch and returns its error or the caught one.
+func run(fn func() error) (err error) {
+	defer Catch(&err)
+
+	return fn()
+}
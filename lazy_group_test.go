@@ -0,0 +1,81 @@
+package lazyerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGroup(t *testing.T) {
+	var g Group
+
+	for _, f := range []func() error{testFuncNoError, testFuncError, testFuncPanic} {
+		f := f
+
+		g.Go(func() error {
+			Try(f())
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err == nil {
+		t.Fatal("unexpected: nil error")
+	}
+
+	g = Group{JoinErrors: true}
+
+	for _, f := range []func() error{testFuncNoError, testFuncError, testFuncPanic} {
+		g.Go(f)
+	}
+
+	err := g.Wait()
+	if !errors.Is(err, ErrPanic) || len(err.(interface{ Unwrap() []error }).Unwrap()) != 2 {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestGroupWithContext(t *testing.T) {
+	g, ctx := GroupWithContext(context.Background())
+
+	g.Go(testFuncPanic)
+	g.Go(func() error {
+		<-ctx.Done()
+
+		return nil
+	})
+
+	if err := g.Wait(); !errors.Is(err, ErrPanic) || !errors.Is(context.Cause(ctx), ErrPanic) {
+		t.Fatal("unexpected:", err, context.Cause(ctx))
+	}
+}
+
+func TestGroupSetLimit(t *testing.T) {
+	var (
+		g              Group
+		active, maxAct atomic.Int32
+	)
+
+	g.SetLimit(2)
+
+	for i := 0; i < 10; i++ {
+		g.Go(func() error {
+			n := active.Add(1)
+			defer active.Add(-1)
+
+			for {
+				if m := maxAct.Load(); n <= m || maxAct.CompareAndSwap(m, n) {
+					return nil
+				}
+			}
+		})
+	}
+
+	if err := g.Wait(); err != nil || maxAct.Load() > 2 {
+		t.Fatal("unexpected:", err, maxAct.Load())
+	}
+}
@@ -0,0 +1,75 @@
+package lazyerrors
+
+type (
+	// Handler - try/catch policy of its own, so subsystems don't depend on defaults of Try and Catch.
+	//
+	//	var db = lazyerrors.New(lazyerrors.WithTry(lazyerrors.TryWrapStackFunc))
+	//
+	//	func query() (err error) {
+	//	        defer db.Catch(&err)
+	//	        db.Try(exec())
+	//
+	//	        return
+	//	}
+	Handler struct {
+		try   func(err error)
+		catch CatchHandler
+	}
+	// HandlerOption - option of New.
+	HandlerOption func(h *Handler)
+)
+
+// New - returns Handler using TryWrapErrorFunc and CatchAllWithStackHandler unless other ones are given in opts.
+func New(opts ...HandlerOption) *Handler {
+	h := &Handler{
+		try:   TryWrapErrorFunc,
+		catch: CatchAllWithStackHandler,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// WithTry - sets try handler fn of Handler (e.g. TryErrorFunc or TryWrapStackFunc), nil is ignored.
+func WithTry(fn func(err error)) HandlerOption {
+	return func(h *Handler) {
+		if fn != nil {
+			h.try = fn
+		}
+	}
+}
+
+// WithCatch - sets catch handler ch of Handler (e.g. CatchErrorHandler or CatchAllHandler), nil is ignored.
+func WithCatch(ch CatchHandler) HandlerOption {
+	return func(h *Handler) {
+		if ch != nil {
+			h.catch = ch
+		}
+	}
+}
+
+// Try - checks error err with the try handler of h.
+func (h *Handler) Try(err error) {
+	h.try(err)
+}
+
+// TryN - checks errors errs one by one with the try handler of h, the first non-nil one is thrown.
+func (h *Handler) TryN(errs ...error) {
+	for _, err := range errs {
+		h.try(err)
+	}
+}
+
+// Catch - catches thrown error or panic with the catch handler of h.
+func (h *Handler) Catch(ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		h.catch(ep, r)
+	}
+}
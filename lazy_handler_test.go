@@ -0,0 +1,43 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	var (
+		wrapping = New()
+		plain    = New(WithTry(TryErrorFunc), WithCatch(CatchAllHandler), WithTry(nil))
+	)
+
+	if err := testWrapper(wrapping.Try, wrapping.Catch, testFuncError); err == nil || (callerEnabled && err.Error() == "test error") {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := testWrapper(wrapping.Try, wrapping.Catch, testFuncPanic); !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := testWrapper(plain.Try, plain.Catch, testFuncError); err == nil || err.Error() != "test error" {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := testWrapper(plain.Try, plain.Catch, testFuncPanic); err == nil || err.Error() != "panic: test panic" {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	err := func() (err error) {
+		defer plain.Catch(&err)
+		plain.TryN(testFuncNoError(), testFuncError(), errors.New("unreachable"))
+
+		return
+	}()
+
+	if err == nil || err.Error() != "test error" {
+		t.Fatal("unexpected:", err)
+	}
+}
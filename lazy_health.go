@@ -0,0 +1,62 @@
+package lazyerrors
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// degradedPeriod - period of degraded state after a recovered panic, set with SetDegradedPeriod.
+var degradedPeriod atomic.Int64
+
+// lastPanic - the latest panic recovered by built-in catch handlers.
+var lastPanic atomic.Pointer[recoveredPanic]
+
+// recoveredPanic - recovered panic and its time.
+type recoveredPanic struct {
+	err  error
+	time time.Time
+}
+
+// SetDegradedPeriod - sets period d after a recovered panic during which Healthy reports degraded state,
+// 0 disables it (default).
+func SetDegradedPeriod(d time.Duration) {
+	degradedPeriod.Store(int64(d))
+}
+
+// Healthy - reports false and the latest recovered panic if it was recovered less than the degraded period ago.
+func Healthy() (bool, error) {
+	p, period := lastPanic.Load(), time.Duration(degradedPeriod.Load())
+	if p == nil || period <= 0 || time.Since(p.time) >= period {
+		return true, nil
+	}
+
+	return false, p.err
+}
+
+// HealthHandler - returns http.Handler that responds with 200 if Healthy, else 503 with message of the latest panic.
+func HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+		if ok, err := Healthy(); !ok {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintf(w, "degraded: %s\n", Message(err))
+
+			return
+		}
+
+		fmt.Fprintln(w, "ok")
+	})
+}
+
+// ResetHealth - forgets the latest recovered panic, so Healthy reports true.
+func ResetHealth() {
+	lastPanic.Store(nil)
+}
+
+// recordPanic - records recovered panic err as the latest one.
+func recordPanic(err error) {
+	lastPanic.Store(&recoveredPanic{err: err, time: time.Now()})
+}
@@ -0,0 +1,45 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHealthy(t *testing.T) {
+	defer SetDegradedPeriod(0)
+	defer ResetHealth()
+
+	_ = testWrapper(Try, Catch, testFuncPanic)
+
+	if ok, err := Healthy(); !ok || err != nil {
+		t.Fatal("unexpected:", ok, err)
+	}
+
+	SetDegradedPeriod(time.Minute)
+
+	if ok, err := Healthy(); ok || !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", ok, err)
+	}
+
+	rec := httptest.NewRecorder()
+	HealthHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+
+	if rec.Code != http.StatusServiceUnavailable || rec.Body.String() != "degraded: test panic\n" {
+		t.Fatal("unexpected:", rec.Code, rec.Body.String())
+	} else {
+		fmt.Print(rec.Body.String())
+	}
+
+	ResetHealth()
+
+	rec = httptest.NewRecorder()
+	HealthHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatal("unexpected:", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,74 @@
+package lazyerrors
+
+import "runtime/trace"
+
+var (
+	// onTry - hooks registered with RegisterOnTry.
+	onTry registry[func(err error)]
+	// onCatch - hooks registered with RegisterOnCatch.
+	onCatch registry[func(err error, recovered bool)]
+)
+
+// RegisterOnTry - registers hook fn called by built-in try handlers with every error they throw.
+func RegisterOnTry(fn func(err error)) {
+	onTry.add(fn)
+}
+
+// RegisterOnCatch - registers hook fn called by built-in catch handlers with every error they catch.
+//
+// Recovered is true if the error was produced from a panic rather than thrown by Try.
+func RegisterOnCatch(fn func(err error, recovered bool)) {
+	onCatch.add(fn)
+}
+
+// ResetHooks - removes all registered OnTry, OnCatch and OnReport hooks, context attribute extractors are kept.
+func ResetHooks() {
+	onTry.reset()
+	onCatch.reset()
+	onReport.reset()
+}
+
+// throw - passes err to OnTry hooks and throws it as a panic, the call site is counted if SetRecordStats enabled it.
+//
+// Trace log event is emitted if runtime/trace is active.
+func throw(err error) {
+	recordStorm()
+
+	if recordStats.Load() {
+		recordCallsite(err)
+	}
+
+	traceThrow(err)
+
+	for _, fn := range onTry.load() {
+		fn(err)
+	}
+
+	panic(err)
+}
+
+// notifyCatch - passes caught err to OnCatch hooks and records it for Recent and Healthy.
+//
+// Hooks are run inside of a trace region if runtime/trace is active.
+func notifyCatch(err error, recovered bool) {
+	recent.add(err, recovered)
+
+	if recovered {
+		recordPanic(err)
+	}
+
+	if trace.IsEnabled() {
+		traceCatch(err, recovered)
+
+		return
+	}
+
+	runCatchHooks(err, recovered)
+}
+
+// runCatchHooks - passes caught err to OnCatch hooks.
+func runCatchHooks(err error, recovered bool) {
+	for _, fn := range onCatch.load() {
+		fn(err, recovered)
+	}
+}
@@ -0,0 +1,42 @@
+package lazyerrors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	defer ResetHooks()
+
+	var (
+		thrown    []error
+		caught    []error
+		recovered []bool
+	)
+
+	RegisterOnTry(func(err error) { thrown = append(thrown, err) })
+	RegisterOnCatch(func(err error, r bool) {
+		caught = append(caught, err)
+		recovered = append(recovered, r)
+	})
+
+	catchers := []func(*error){CatchAllWithStackFunc, CatchAllFunc, CatchJoin}
+
+	for _, catch := range catchers {
+		for _, f := range []func() error{testFuncNoError, testFuncError, testFuncPanic} {
+			_ = testWrapper(TryWrapErrorFunc, catch, f)
+		}
+	}
+
+	if len(thrown) != len(catchers) || len(caught) != 2*len(catchers) {
+		t.Fatal("unexpected:", thrown, caught)
+	}
+
+	for i := range catchers {
+		if recovered[2*i] || !recovered[2*i+1] {
+			t.Fatal("unexpected:", recovered)
+		}
+	}
+
+	fmt.Println(thrown, recovered)
+}
@@ -0,0 +1,75 @@
+package lazyerrors
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// recordHops - records hops of lazy errors, set with SetRecordHops.
+var recordHops atomic.Bool
+
+// SetRecordHops - enables or disables recording a hop each time an already wrapped lazy error passes through Try,
+// it's disabled by default.
+func SetRecordHops(on bool) {
+	recordHops.Store(on)
+}
+
+// Hops - returns callers of Try the error passed through after it was wrapped (requires SetRecordHops).
+func (e *LazyErrorWithCaller) Hops() []Frame {
+	return e.hops
+}
+
+// Hops - returns callers of Try the error passed through after it was wrapped (requires SetRecordHops).
+func (e *LazyErrorWithStack) Hops() []Frame {
+	return e.hops
+}
+
+// Hops - returns callers of Try the error passed through after it was wrapped (requires SetRecordHops).
+func (e *LazyErrorFromPanic) Hops() []Frame {
+	return e.hops
+}
+
+// withHop - returns a copy of lazy error err with the current caller appended to its hops, err is returned as is
+// if callers aren't captured. The error itself isn't modified, since it can be rethrown concurrently.
+func withHop(err error) error {
+	if !CapturesCaller() {
+		return err
+	}
+
+	hop := caller()
+
+	switch t := err.(type) {
+	case *LazyErrorWithCaller:
+		e := *t
+		e.hops = appendHop(t.hops, hop)
+
+		return &e
+	case *LazyErrorWithStack:
+		e := *t
+		e.hops = appendHop(t.hops, hop)
+
+		return &e
+	case *LazyErrorFromPanic:
+		e := *t
+		e.hops = appendHop(t.hops, hop)
+
+		return &e
+	default:
+		return err
+	}
+}
+
+// appendHop - returns a new slice of hops with hop appended, hops are never modified.
+func appendHop(hops []Frame, hop Frame) []Frame {
+	return append(hops[:len(hops):len(hops)], hop)
+}
+
+// hopsOf - returns hops of the outermost lazy error in the chain of err.
+func hopsOf(err error) []Frame {
+	var e interface{ Hops() []Frame }
+	if errors.As(err, &e) {
+		return e.Hops()
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package lazyerrors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRecordHops(t *testing.T) {
+	skipWithoutCaller(t)
+
+	defer SetRecordHops(false)
+
+	nested := func() error {
+		return testWrapper(TryWrapErrorFunc, CatchAllFunc, func() error {
+			return testWrapper(TryWrapErrorFunc, CatchAllFunc, testFuncError)
+		})
+	}
+
+	if hops := nested().(*LazyErrorWithCaller).Hops(); len(hops) != 0 {
+		t.Fatal("unexpected:", hops)
+	}
+
+	SetRecordHops(true)
+
+	if hops := nested().(*LazyErrorWithCaller).Hops(); len(hops) != 1 {
+		t.Fatal("unexpected:", hops)
+	} else {
+		fmt.Printf("%+v\n", hops)
+	}
+
+	err := testWrapper(TryWrapStackFunc, CatchAllWithStackFunc, func() error {
+		return testWrapper(TryWrapStackFunc, CatchAllWithStackFunc, testFuncPanic)
+	})
+	if hops := err.(*LazyErrorFromPanic).Hops(); len(hops) != 1 {
+		t.Fatal("unexpected:", hops)
+	}
+}
+
+func TestRecordHopsConcurrent(t *testing.T) {
+	skipWithoutCaller(t)
+
+	defer SetRecordHops(false)
+
+	SetRecordHops(true)
+
+	f := Async(func() (int, error) { return 0, testFuncError() })
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if err := Do(func() { f.Get() }); len(err.(*LazyErrorWithCaller).Hops()) != 1 {
+				t.Error("unexpected:", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if _, err := f.Wait(); len(err.(*LazyErrorWithCaller).Hops()) != 0 {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestRenderHops(t *testing.T) {
+	skipWithoutCaller(t)
+
+	defer SetRecordHops(false)
+
+	SetRecordHops(true)
+
+	err := testWrapper(TryWrapErrorFunc, CatchAllFunc, func() error {
+		return testWrapper(TryWrapErrorFunc, CatchAllFunc, testFuncError)
+	})
+
+	report := fmt.Sprintf("%+v", err)
+	if !strings.Contains(report, "hops:\n\t") {
+		t.Fatal("unexpected:", report)
+	} else {
+		fmt.Print(report)
+	}
+
+	if data, jsonErr := json.Marshal(err); jsonErr != nil || !strings.Contains(string(data), `"hops":[{`) {
+		t.Fatal("unexpected:", string(data), jsonErr)
+	}
+}
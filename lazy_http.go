@@ -0,0 +1,202 @@
+package lazyerrors
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+)
+
+// HTTPErrorWriter - writer of responses of errors caught by HTTPMiddleware.
+type HTTPErrorWriter func(w http.ResponseWriter, r *http.Request, err error)
+
+var (
+	// httpStatus - status code mapper set with SetHTTPStatus, StatusOf if nil.
+	httpStatus atomic.Pointer[func(err error) int]
+	// httpErrorWriter - writer set with SetHTTPErrorWriter, the status code is written with its text if nil.
+	httpErrorWriter atomic.Pointer[HTTPErrorWriter]
+)
+
+// SetHTTPStatus - sets fn returning response status code of error caught by HTTPMiddleware, nil restores StatusOf.
+func SetHTTPStatus(fn func(err error) int) {
+	if fn == nil {
+		httpStatus.Store(nil)
+
+		return
+	}
+
+	httpStatus.Store(&fn)
+}
+
+// SetHTTPErrorWriter - sets optional writer w of responses of errors caught by HTTPMiddleware, if nil the status
+// code set with SetHTTPStatus is written with its text, so error messages don't leak to clients.
+func SetHTTPErrorWriter(w HTTPErrorWriter) {
+	if w == nil {
+		httpErrorWriter.Store(nil)
+
+		return
+	}
+
+	httpErrorWriter.Store(&w)
+}
+
+// httpErrorKey - context key of httpError set by HTTPMiddleware or HTTPErrorContext.
+type httpErrorKey struct{}
+
+// httpError - slot of the error caught while serving a request.
+type httpError struct {
+	err error
+}
+
+// HTTPMiddleware - returns http.Handler serving requests with next under Catch, thrown errors and panics
+// are turned into responses with the writer set with SetHTTPErrorWriter. The caught error is available to logging middleware
+// with HTTPErrorFromContext.
+//
+// Panics with http.ErrAbortHandler keep panicking, so net/http aborts the response. No error response is written
+// if next has already started writing its own.
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r, slot := withHTTPError(r)
+
+		rw := &httpResponseWriter{ResponseWriter: w}
+
+		if err := Do(func() { next.ServeHTTP(rw, r) }); err != nil {
+			serveHTTPError(rw, r, slot, err)
+		}
+	})
+}
+
+// HandlerE - returns http.Handler serving requests with fn under Catch, so fn can use Try freely.
+//
+// Returned or thrown errors and panics are handled like HTTPMiddleware does, with status codes of SetHTTPStatus.
+//
+//	http.Handle("/users", lazyerrors.HandlerE(func(w http.ResponseWriter, r *http.Request) error {
+//	        user := lazyerrors.Try1(findUser(r.URL.Query().Get("id")))
+//
+//	        return json.NewEncoder(w).Encode(user)
+//	}))
+func HandlerE(fn func(w http.ResponseWriter, r *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r, slot := withHTTPError(r)
+
+		rw := &httpResponseWriter{ResponseWriter: w}
+
+		if err := attempt(func() error { return fn(rw, r) }); err != nil {
+			serveHTTPError(rw, r, slot, err)
+		}
+	})
+}
+
+// HTTPErrorContext - returns a copy of ctx able to carry the error caught by HTTPMiddleware, so middleware
+// wrapping HTTPMiddleware can get it with HTTPErrorFromContext after the request is served.
+func HTTPErrorContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, httpErrorKey{}, &httpError{})
+}
+
+// HTTPErrorFromContext - returns error caught by HTTPMiddleware while serving the request of ctx, nil if there's none.
+func HTTPErrorFromContext(ctx context.Context) error {
+	if slot, ok := ctx.Value(httpErrorKey{}).(*httpError); ok {
+		return slot.err
+	}
+
+	return nil
+}
+
+// withHTTPError - returns request r carrying slot of the caught error, the slot r already carries is reused.
+func withHTTPError(r *http.Request) (*http.Request, *httpError) {
+	if slot, ok := r.Context().Value(httpErrorKey{}).(*httpError); ok {
+		return r, slot
+	}
+
+	slot := &httpError{}
+
+	return r.WithContext(context.WithValue(r.Context(), httpErrorKey{}, slot)), slot
+}
+
+// httpResponseWriter - http.ResponseWriter remembering whether the handler has started the response.
+type httpResponseWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+// WriteHeader - implements http.ResponseWriter.
+func (w *httpResponseWriter) WriteHeader(code int) {
+	w.wrote = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write - implements http.ResponseWriter.
+func (w *httpResponseWriter) Write(b []byte) (int, error) {
+	w.wrote = true
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush - implements http.Flusher if the underlying writer does.
+func (w *httpResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wrote = true
+		f.Flush()
+	}
+}
+
+// Hijack - implements http.Hijacker, http.ErrNotSupported is returned if the underlying writer isn't one.
+func (w *httpResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+
+	w.wrote = true
+
+	return h.Hijack()
+}
+
+// Push - implements http.Pusher, http.ErrNotSupported is returned if the underlying writer isn't one.
+func (w *httpResponseWriter) Push(target string, opts *http.PushOptions) error {
+	if p, ok := w.ResponseWriter.(http.Pusher); ok {
+		return p.Push(target, opts)
+	}
+
+	return http.ErrNotSupported
+}
+
+// ReadFrom - implements io.ReaderFrom, so the underlying writer can use sendfile.
+func (w *httpResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	w.wrote = true
+
+	return io.Copy(w.ResponseWriter, r)
+}
+
+// Unwrap - returns the underlying writer for http.ResponseController.
+func (w *httpResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// serveHTTPError - records caught error err into slot and writes its response, unless the handler has started one.
+func serveHTTPError(w *httpResponseWriter, r *http.Request, slot *httpError, err error) {
+	if err == http.ErrAbortHandler {
+		panic(err)
+	}
+
+	slot.err = err
+
+	if w.wrote {
+		return
+	}
+
+	if fn := httpErrorWriter.Load(); fn != nil {
+		(*fn)(w, r, err)
+
+		return
+	}
+
+	code := StatusOf(err)
+	if fn := httpStatus.Load(); fn != nil {
+		code = (*fn)(err)
+	}
+
+	http.Error(w, http.StatusText(code), code)
+}
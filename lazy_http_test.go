@@ -0,0 +1,154 @@
+package lazyerrors
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	var logged error
+
+	logging := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r = r.WithContext(HTTPErrorContext(r.Context()))
+			next.ServeHTTP(w, r)
+			logged = HTTPErrorFromContext(r.Context())
+		})
+	}
+
+	for _, testCase := range []struct {
+		f    func() error
+		code int
+	}{
+		{testFuncNoError, http.StatusOK},
+		{testFuncError, http.StatusInternalServerError},
+		{testFuncPanic, http.StatusInternalServerError},
+	} {
+		h := logging(HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			Try(testCase.f())
+			fmt.Fprint(w, "ok")
+		})))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+		if rec.Code != testCase.code || (rec.Code == http.StatusOK) != (logged == nil) {
+			t.Fatal("unexpected:", rec.Code, logged)
+		} else if logged != nil {
+			fmt.Println(rec.Code, logged)
+		}
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != http.ErrAbortHandler {
+				t.Fatal("unexpected:", r)
+			}
+		}()
+
+		HTTPMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic(http.ErrAbortHandler)
+		})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	}()
+}
+
+func TestHTTPErrorWriter(t *testing.T) {
+	defer SetHTTPErrorWriter(nil)
+
+	SetHTTPErrorWriter(func(w http.ResponseWriter, r *http.Request, err error) {
+		if errors.Is(err, ErrPanic) && HTTPErrorFromContext(r.Context()) == err {
+			w.WriteHeader(http.StatusTeapot)
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	HTTPMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		Try(testFuncPanic())
+	})).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+	if rec.Code != http.StatusTeapot {
+		t.Fatal("unexpected:", rec.Code)
+	}
+}
+
+func TestHandlerE(t *testing.T) {
+	defer ResetStatus()
+
+	MapStatus(io.EOF, http.StatusBadRequest)
+
+	for _, testCase := range []struct {
+		f    func() error
+		code int
+	}{
+		{testFuncNoError, http.StatusOK},
+		{func() error { return io.EOF }, http.StatusBadRequest},
+		{func() error { Try(io.EOF); return nil }, http.StatusBadRequest},
+		{testFuncPanic, http.StatusInternalServerError},
+	} {
+		rec := httptest.NewRecorder()
+		HandlerE(func(w http.ResponseWriter, _ *http.Request) error {
+			return testCase.f()
+		}).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+		if rec.Code != testCase.code {
+			t.Fatal("unexpected:", rec.Code)
+		} else {
+			fmt.Print(rec.Code, " ", rec.Body.String())
+		}
+	}
+}
+
+func TestHTTPPartialResponse(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	req = req.WithContext(HTTPErrorContext(req.Context()))
+
+	HandlerE(func(w http.ResponseWriter, _ *http.Request) error {
+		fmt.Fprint(w, "partial")
+
+		return testFuncError()
+	}).ServeHTTP(rec, req)
+
+	if err := HTTPErrorFromContext(req.Context()); rec.Code != http.StatusOK || rec.Body.String() != "partial" || err == nil {
+		t.Fatal("unexpected:", rec.Code, rec.Body.String(), err)
+	}
+}
+
+type testHijacker struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+func (w *testHijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.hijacked = true
+
+	return nil, nil, nil
+}
+
+func TestHTTPHijack(t *testing.T) {
+	for _, h := range []http.Handler{
+		HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _, _ = w.(http.Hijacker).Hijack()
+			Try(testFuncError())
+		})),
+		HandlerE(func(w http.ResponseWriter, _ *http.Request) error {
+			_, _, _ = w.(http.Hijacker).Hijack()
+
+			return testFuncError()
+		}),
+	} {
+		w := &testHijacker{ResponseRecorder: httptest.NewRecorder()}
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		// no error response is written to a hijacked connection.
+		if !w.hijacked || w.Body.Len() != 0 {
+			t.Fatal("unexpected:", w.hijacked, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package lazyerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+type (
+	// jsonError - structured JSON representation of lazy errors.
+	jsonError struct {
+		Message   string                 `json:"message"`
+		Caller    string                 `json:"caller,omitempty"`
+		Recovered string                 `json:"recovered,omitempty"`
+		Kind      string                 `json:"kind,omitempty"`
+		Goroutine uint64                 `json:"goroutine,omitempty"`
+		Labels    map[string]string      `json:"labels,omitempty"`
+		Attrs     map[string]interface{} `json:"attrs,omitempty"`
+		Frames    []jsonFrame            `json:"frames,omitempty"`
+		Hops      []jsonFrame            `json:"hops,omitempty"`
+		Origin    []jsonFrame            `json:"origin,omitempty"`
+		Chain     []string               `json:"chain,omitempty"`
+		Errors    []json.RawMessage      `json:"errors,omitempty"`
+	}
+	// jsonFrame - structured JSON representation of Frame.
+	jsonFrame struct {
+		Function string `json:"function"`
+		File     string `json:"file"`
+		Line     int    `json:"line"`
+	}
+)
+
+// MarshalJSON - json.Marshaler interface implementation.
+func (e *LazyErrorWithCaller) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toJSON())
+}
+
+// MarshalJSON - json.Marshaler interface implementation.
+func (e *LazyErrorWithStack) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toJSON())
+}
+
+// MarshalJSON - json.Marshaler interface implementation.
+func (e *LazyErrorFromPanic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toJSON())
+}
+
+// toJSON - returns JSON representation of the error.
+func (e *LazyErrorWithCaller) toJSON() jsonError {
+	return jsonError{
+		Message: redact(truncateMessage(e.Err.Error())),
+		Caller:  strings.TrimSuffix(e.Caller, ": "),
+		Attrs:   attrsMap(Attrs(e.Err)),
+		Frames:  newJSONFrames(e.Frames()),
+		Hops:    newJSONFrames(e.hops),
+		Chain:   chain(e.Err),
+		Errors:  jsonBranches(e.Err),
+	}
+}
+
+// toJSON - returns JSON representation of the error.
+func (e *LazyErrorWithStack) toJSON() jsonError {
+	frames := e.Frames()
+
+	var caller string
+	if len(frames) > 0 {
+		caller = strings.TrimSuffix(frames[0].caller(), ": ")
+	}
+
+	return jsonError{
+		Message: redact(truncateMessage(e.Err.Error())),
+		Caller:  caller,
+		Attrs:   attrsMap(Attrs(e.Err)),
+		Frames:  newJSONFrames(frames),
+		Hops:    newJSONFrames(e.hops),
+		Chain:   chain(e.Err),
+		Errors:  jsonBranches(e.Err),
+	}
+}
+
+// toJSON - returns JSON representation of the error.
+func (e *LazyErrorFromPanic) toJSON() jsonError {
+	return jsonError{
+		Message:   ErrPanic.Error(),
+		Recovered: redact(e.recovered()),
+		Kind:      e.Kind.jsonString(),
+		Goroutine: e.GoroutineID,
+		Labels:    e.Labels,
+		Attrs:     attrsMap(Attrs(e)),
+		Frames:    newJSONFrames(e.Frames()),
+		Hops:      newJSONFrames(e.hops),
+	}
+}
+
+// lazyJSON - returns JSON representation of the first lazy error in the chain of err followed with errors.Unwrap
+// up to a multi-error, the message and attributes are taken from err itself.
+func lazyJSON(err error) (jsonError, bool) {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if _, ok := e.(multiError); ok {
+			break
+		}
+
+		if l, ok := e.(interface{ toJSON() jsonError }); ok {
+			out := l.toJSON()
+			out.Attrs = attrsMap(Attrs(err))
+
+			if msg := Message(err); msg != Message(e) {
+				out.Message = truncateMessage(msg)
+			}
+
+			return out, true
+		}
+	}
+
+	return jsonError{}, false
+}
+
+// newJSONFrames - converts frames into their JSON representation (the trimmer set with SetTrimPath is applied).
+func newJSONFrames(frames []Frame) []jsonFrame {
+	if len(frames) == 0 {
+		return nil
+	}
+
+	out := make([]jsonFrame, 0, len(frames))
+	for _, frame := range frames {
+		out = append(out, jsonFrame{
+			Function: frame.Function,
+			File:     frame.file(),
+			Line:     frame.Line,
+		})
+	}
+
+	return out
+}
+
+// chain - returns messages of errors wrapped by err (err included), nil if nothing was wrapped.
+//
+// LazyErrorWithAttrs, LazyErrorWithContext and LazyErrorWithClass are skipped, since their messages are the same as of the wrapped error.
+func chain(err error) []string {
+	var messages []string
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		switch err.(type) {
+		case *LazyErrorWithAttrs, *LazyErrorWithContext, *LazyErrorWithClass:
+		default:
+			messages = append(messages, redact(err.Error()))
+		}
+	}
+
+	if len(messages) < 2 {
+		return nil
+	}
+
+	return messages
+}
@@ -0,0 +1,29 @@
+package lazyerrors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	for _, err := range []error{
+		testWrapper(TryWrapErrorFunc, CatchAllFunc, func() error { return fmt.Errorf("test: %w", io.EOF) }),
+		testWrapper(TryWrapStackFunc, CatchAllFunc, testFuncError),
+		testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, testFuncPanic),
+	} {
+		data, jsonErr := json.Marshal(err)
+		if jsonErr != nil {
+			t.Fatal("unexpected:", jsonErr)
+		}
+
+		var decoded map[string]interface{}
+
+		if jsonErr := json.Unmarshal(data, &decoded); jsonErr != nil || decoded["message"] == "" || (callerEnabled && decoded["frames"] == nil) {
+			t.Fatal("unexpected:", string(data), jsonErr)
+		} else {
+			fmt.Println(string(data))
+		}
+	}
+}
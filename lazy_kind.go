@@ -0,0 +1,118 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// PanicKind - classification of a recovered panic.
+type PanicKind uint8
+
+const (
+	// PanicValue - panic with an arbitrary value, not a runtime error.
+	PanicValue PanicKind = iota
+	// PanicRuntime - runtime error of unknown kind.
+	PanicRuntime
+	// PanicNilDereference - nil pointer dereference.
+	PanicNilDereference
+	// PanicIndexOutOfRange - index out of range.
+	PanicIndexOutOfRange
+	// PanicSliceBounds - slice bounds out of range.
+	PanicSliceBounds
+	// PanicDivideByZero - integer divide by zero.
+	PanicDivideByZero
+	// PanicNilMap - assignment to entry in nil map.
+	PanicNilMap
+	// PanicTypeAssertion - failed type assertion.
+	PanicTypeAssertion
+	// PanicClosedChannel - send on closed channel or close of closed or nil channel.
+	PanicClosedChannel
+)
+
+// panicKinds - headlines of panic kinds.
+var panicKinds = [...]string{
+	PanicValue:           "panic",
+	PanicRuntime:         "runtime error",
+	PanicNilDereference:  "nil pointer dereference",
+	PanicIndexOutOfRange: "index out of range",
+	PanicSliceBounds:     "slice bounds out of range",
+	PanicDivideByZero:    "integer divide by zero",
+	PanicNilMap:          "assignment to nil map",
+	PanicTypeAssertion:   "failed type assertion",
+	PanicClosedChannel:   "closed channel",
+}
+
+// runtimeMessages - runtime error messages mapped to panic kinds.
+var runtimeMessages = []struct {
+	substr string
+	kind   PanicKind
+}{
+	{"nil pointer dereference", PanicNilDereference},
+	{"index out of range", PanicIndexOutOfRange},
+	{"slice bounds out of range", PanicSliceBounds},
+	{"divide by zero", PanicDivideByZero},
+	{"entry in nil map", PanicNilMap},
+	{"closed channel", PanicClosedChannel},
+	{"nil channel", PanicClosedChannel},
+}
+
+// String - fmt.Stringer interface implementation.
+func (k PanicKind) String() string {
+	if int(k) < len(panicKinds) {
+		return panicKinds[k]
+	}
+
+	return fmt.Sprintf("PanicKind(%d)", k)
+}
+
+// jsonString - returns the kind for JSON output, empty for PanicValue.
+func (k PanicKind) jsonString() string {
+	if k == PanicValue {
+		return ""
+	}
+
+	return k.String()
+}
+
+// headline - returns report headline of the panic, its kind included if it's a runtime error.
+func (e *LazyErrorFromPanic) headline() string {
+	if e.Kind != PanicValue {
+		return fmt.Sprintf("%v recovered: %s", ErrPanic, e.Kind)
+	}
+
+	return fmt.Sprintf("%v recovered", ErrPanic)
+}
+
+// classifyPanic - classifies recovered value r.
+func classifyPanic(r interface{}) PanicKind {
+	err, ok := r.(error)
+	if !ok || !isRuntimeError(err) {
+		return PanicValue
+	}
+
+	var assertionErr *runtime.TypeAssertionError
+	if errors.As(err, &assertionErr) {
+		return PanicTypeAssertion
+	}
+
+	msg := err.Error()
+	for _, m := range runtimeMessages {
+		if strings.Contains(msg, m.substr) {
+			return m.kind
+		}
+	}
+
+	return PanicRuntime
+}
+
+// isRuntimeError - reports whether err itself is a runtime.Error.
+//
+// Wrapped errors aren't inspected, so thrown errors that wrap a recovered runtime error (e.g. a caught
+// LazyErrorFromPanic or a join containing it) aren't mistaken for a new panic.
+func isRuntimeError(err error) bool {
+	_, ok := err.(runtime.Error)
+
+	return ok
+}
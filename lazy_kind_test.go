@@ -0,0 +1,61 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestPanicKind(t *testing.T) {
+	var (
+		nilPtr   *LazyErrorWithCaller
+		nilMap   map[string]int
+		slice    []int
+		zero     int
+		value    interface{} = "test"
+		closedCh             = make(chan int)
+	)
+
+	close(closedCh)
+
+	for kind, f := range map[PanicKind]func() error{
+		PanicValue:           testFuncPanic,
+		PanicNilDereference:  func() error { return nilPtr.Err },
+		PanicIndexOutOfRange: func() error { _ = slice[len(slice)+1]; return nil },
+		PanicSliceBounds:     func() error { _ = slice[:len(slice)+1]; return nil },
+		PanicDivideByZero:    func() error { _ = 1 / zero; return nil },
+		PanicNilMap:          func() error { nilMap["test"] = 1; return nil },
+		PanicTypeAssertion:   func() error { _ = value.(int); return nil },
+		PanicClosedChannel:   func() error { close(closedCh); return nil },
+	} {
+		err := testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, f)
+
+		var panicErr *LazyErrorFromPanic
+
+		if !errors.As(err, &panicErr) || panicErr.Kind != kind || !strings.Contains(err.Error(), kind.String()) {
+			t.Fatal("unexpected:", kind, err)
+		}
+
+		var runtimeErr runtime.Error
+
+		if errors.As(err, &runtimeErr) != (kind != PanicValue) {
+			t.Fatal("unexpected:", kind, err)
+		}
+
+		fmt.Println(strings.SplitN(err.Error(), "\n", 2)[0])
+	}
+}
+
+func TestMultiRethrow(t *testing.T) {
+	panicked := testWrapper(Try, Catch, func() error { var m map[string]int; m["key"] = 1; return nil })
+
+	// a rethrown join containing a recovered runtime error is caught as a thrown error, not as a new panic.
+	err := testWrapper(TryErrorFunc, Catch, func() error { return errors.Join(io.EOF, panicked) })
+
+	if _, ok := err.(*LazyErrorFromPanic); ok || !errors.Is(err, io.EOF) || !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+}
@@ -0,0 +1,69 @@
+package lazyerrors
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogHandler - slog.Handler middleware that promotes fields of lazy errors found in record attributes.
+//
+// An error attribute is replaced with its message under the same key, while caller, stack, panic
+// and attributes attached with With are added as top-level attributes (see LogAttrs).
+type LogHandler struct {
+	next slog.Handler
+}
+
+// NewLogHandler - returns LogHandler that passes enriched records to next.
+func NewLogHandler(next slog.Handler) *LogHandler {
+	return &LogHandler{next: next}
+}
+
+// Enabled - slog.Handler interface implementation.
+func (h *LogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle - slog.Handler interface implementation.
+func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	enriched := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+
+	r.Attrs(func(attr slog.Attr) bool {
+		enriched.AddAttrs(enrichAttr(attr)...)
+
+		return true
+	})
+
+	return h.next.Handle(ctx, enriched)
+}
+
+// WithAttrs - slog.Handler interface implementation.
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	enriched := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		enriched = append(enriched, enrichAttr(attr)...)
+	}
+
+	return &LogHandler{next: h.next.WithAttrs(enriched)}
+}
+
+// WithGroup - slog.Handler interface implementation.
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{next: h.next.WithGroup(name)}
+}
+
+// enrichAttr - returns attribute attr as is, or attributes of its error value if there's something to promote.
+func enrichAttr(attr slog.Attr) []slog.Attr {
+	err, ok := attr.Value.Resolve().Any().(error)
+	if !ok || err == nil {
+		return []slog.Attr{attr}
+	}
+
+	attrs := LogAttrs(err)
+	if len(attrs) == 1 {
+		return []slog.Attr{attr}
+	}
+
+	attrs[0].Key = attr.Key
+
+	return attrs
+}
@@ -0,0 +1,37 @@
+package lazyerrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+)
+
+func TestLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(NewLogHandler(slog.NewJSONHandler(&buf, nil)))
+
+	for _, f := range []func() error{testFuncError, testFuncPanic} {
+		err := testWrapper(Try, Catch, f)
+		logger.With("plain", errors.New("plain error")).Error("failed", "err", err)
+	}
+
+	decoder := json.NewDecoder(&buf)
+
+	for _, key := range []string{"caller", "stack"} {
+		var record map[string]interface{}
+
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatal("unexpected:", err)
+		}
+
+		if (callerEnabled && record[key] == nil) || record["err"] == nil || record["plain"] != "plain error" {
+			t.Fatal("unexpected:", record)
+		} else {
+			fmt.Println(record)
+		}
+	}
+}
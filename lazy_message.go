@@ -0,0 +1,82 @@
+package lazyerrors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// messager - error able to return its text without annotations.
+type messager interface {
+	Message() string
+}
+
+// Message - returns text of error err without caller and stack annotations added by this package, annotations
+// of lazy errors wrapped by other errors (e.g. with fmt.Errorf) are stripped too.
+func Message(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	if m, ok := err.(messager); ok {
+		return redact(m.Message())
+	}
+
+	return redact(stripAnnotations(err.Error(), err))
+}
+
+// stripAnnotations - replaces texts of lazy errors wrapped by err with their messages in text of err.
+func stripAnnotations(text string, err error) string {
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if inner := u.Unwrap(); inner != nil {
+			if m, ok := inner.(messager); ok {
+				return strings.Replace(text, inner.Error(), m.Message(), 1)
+			}
+
+			return stripAnnotations(text, inner)
+		}
+	case interface{ Unwrap() []error }:
+		for _, inner := range u.Unwrap() {
+			if m, ok := inner.(messager); ok {
+				text = strings.Replace(text, inner.Error(), m.Message(), 1)
+			} else if inner != nil {
+				text = stripAnnotations(text, inner)
+			}
+		}
+	}
+
+	return text
+}
+
+// Message - returns text of the wrapped error without the caller prefix.
+func (e *LazyErrorWithCaller) Message() string {
+	return Message(e.Err)
+}
+
+// Message - returns text of the wrapped error without the caller prefix.
+func (e *LazyErrorWithStack) Message() string {
+	return Message(e.Err)
+}
+
+// Message - returns text of the recovered value without the stack.
+func (e *LazyErrorFromPanic) Message() string {
+	return redact(fmt.Sprint(e.Recovered))
+}
+
+// Message - returns text of the wrapped error without the origin stack.
+func (e *LazyErrorWithOrigin) Message() string {
+	return Message(e.Err)
+}
+
+// Message - returns text of the step error without annotations of the wrapped error.
+func (e *LazyErrorWithStep) Message() string {
+	return (&LazyErrorWithStep{Err: plainError(Message(e.Err)), Name: e.Name, Index: e.Index}).Error()
+}
+
+// plainError - error with a fixed message.
+type plainError string
+
+// Error - error interface implementation.
+func (e plainError) Error() string {
+	return string(e)
+}
@@ -0,0 +1,43 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestMessage(t *testing.T) {
+	for _, err := range []error{
+		testWrapper(TryWrapErrorFunc, CatchAllFunc, testFuncError),
+		testWrapper(TryWrapStackFunc, CatchAllFunc, testFuncError),
+		NewOrigin().Wrap(testWrapper(TryWrapErrorFunc, CatchAllFunc, testFuncError)),
+		testFuncError(),
+	} {
+		if msg := Message(err); msg != "test error" {
+			t.Fatal("unexpected:", msg)
+		}
+	}
+
+	if msg := Message(testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, testFuncPanic)); msg != "test panic" {
+		t.Fatal("unexpected:", msg)
+	}
+
+	if msg := Message(Chain().ThenNamed("test", testFuncError).Run()); msg != "step 0 (test): test error" {
+		t.Fatal("unexpected:", msg)
+	}
+
+	wrapped := testWrapper(TryWrapErrorFunc, CatchAllFunc, testFuncError)
+
+	if msg := Message(fmt.Errorf("ctx: %w", wrapped)); msg != "ctx: test error" {
+		t.Fatal("unexpected:", msg)
+	}
+
+	if msg := Message(errors.Join(io.EOF, fmt.Errorf("ctx: %w", wrapped))); msg != "EOF\nctx: test error" {
+		t.Fatal("unexpected:", msg)
+	}
+
+	if msg := Message(nil); msg != "" {
+		t.Fatal("unexpected:", msg)
+	}
+}
@@ -0,0 +1,47 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Metrics - collector of Try throws, Catch recoveries and recovered panics.
+type Metrics interface {
+	// ObserveThrow - counts error err thrown by a built-in try handler.
+	ObserveThrow(err error)
+	// ObserveCatch - counts error err caught by a built-in catch handler, recovered is true for panics.
+	ObserveCatch(err error, recovered bool)
+}
+
+// RegisterMetrics - registers OnTry and OnCatch hooks that pass thrown and caught errors to m.
+func RegisterMetrics(m Metrics) {
+	RegisterOnTry(m.ObserveThrow)
+	RegisterOnCatch(m.ObserveCatch)
+}
+
+// ErrorType - returns type name of the innermost error wrapped by err, recovered panics are named by their PanicKind.
+func ErrorType(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var fromPanic *LazyErrorFromPanic
+	if errors.As(err, &fromPanic) {
+		return fromPanic.Kind.String()
+	}
+
+	for next := errors.Unwrap(err); next != nil; next = errors.Unwrap(next) {
+		err = next
+	}
+
+	return fmt.Sprintf("%T", err)
+}
+
+// ErrorPackage - returns package of the call site where error err was wrapped or recovered, empty if it's unknown.
+func ErrorPackage(err error) string {
+	if frame := topFrame(err); frame != (Frame{}) {
+		return frame.Package()
+	}
+
+	return ""
+}
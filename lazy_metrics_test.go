@@ -0,0 +1,53 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type testMetrics struct {
+	throws, catches, panics int
+	types, packages         []string
+}
+
+func (m *testMetrics) ObserveThrow(error) {
+	m.throws++
+}
+
+func (m *testMetrics) ObserveCatch(err error, recovered bool) {
+	if recovered {
+		m.panics++
+	} else {
+		m.catches++
+	}
+
+	m.types = append(m.types, ErrorType(err))
+	m.packages = append(m.packages, ErrorPackage(err))
+}
+
+func TestRegisterMetrics(t *testing.T) {
+	defer ResetHooks()
+
+	m := &testMetrics{}
+
+	RegisterMetrics(m)
+
+	for _, f := range []func() error{testFuncNoError, testFuncError, testFuncPanic} {
+		_ = testWrapper(Try, Catch, f)
+	}
+
+	if m.throws != 1 || m.catches != 1 || m.panics != 1 {
+		t.Fatal("unexpected:", m)
+	}
+
+	if m.types[0] != "*errors.errorString" || m.types[1] != "panic" || (callerEnabled && m.packages[0] != packagePrefix[:len(packagePrefix)-1]) {
+		t.Fatal("unexpected:", m.types, m.packages)
+	} else {
+		fmt.Println(m.types, m.packages)
+	}
+
+	if ErrorType(nil) != "" || ErrorPackage(errors.New("test error")) != "" {
+		t.Fatal("unexpected: non-empty labels")
+	}
+}
@@ -0,0 +1,218 @@
+package lazyerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// multiError - error wrapping several errors, e.g. produced by errors.Join.
+type multiError interface {
+	error
+	Unwrap() []error
+}
+
+// Format - fmt.Formatter interface implementation, %+v writes the report in the same way as WriteReport does.
+func (e *LazyErrorWithCaller) Format(s fmt.State, verb rune) {
+	format(s, verb, e)
+}
+
+// Format - fmt.Formatter interface implementation, %+v writes the report in the same way as WriteReport does.
+func (e *LazyErrorWithStack) Format(s fmt.State, verb rune) {
+	format(s, verb, e)
+}
+
+// Format - fmt.Formatter interface implementation, %+v writes the report in the same way as WriteReport does.
+func (e *LazyErrorFromPanic) Format(s fmt.State, verb rune) {
+	format(s, verb, e)
+}
+
+// Detailed - returns fmt.Formatter of error err, so %+v writes its report even if err isn't a lazy error
+// (e.g. errors.Join of lazy errors).
+func Detailed(err error) fmt.Formatter {
+	return detailed{err}
+}
+
+// MarshalError - returns JSON representation of error err, branches of multi-errors are marshaled one by one.
+//
+// Lazy errors wrapped by other errors are marshaled with their caller, stack and attributes.
+func MarshalError(err error) ([]byte, error) {
+	if err == nil {
+		return []byte("null"), nil
+	}
+
+	if m, ok := err.(json.Marshaler); ok {
+		return m.MarshalJSON()
+	}
+
+	if out, ok := lazyJSON(err); ok {
+		return json.Marshal(out)
+	}
+
+	return json.Marshal(jsonError{
+		Message: Message(err),
+		Errors:  jsonBranches(err),
+	})
+}
+
+// detailed - fmt.Formatter of an arbitrary error, see Detailed.
+type detailed struct {
+	err error
+}
+
+// Format - fmt.Formatter interface implementation.
+func (d detailed) Format(s fmt.State, verb rune) {
+	if d.err == nil {
+		fmt.Fprintf(s, fmt.FormatString(s, verb), d.err)
+
+		return
+	}
+
+	format(s, verb, d.err)
+}
+
+// format - writes report of error err for %+v, else formats its message according to verb.
+func format(s fmt.State, verb rune, err error) {
+	if verb == 'v' && s.Flag('+') {
+		WriteReport(s, err)
+
+		return
+	}
+
+	fmt.Fprintf(s, fmt.FormatString(s, verb), err.Error())
+}
+
+// walk - calls fn with err and every error wrapped by it depth-first (branches of multi-errors included)
+// until fn returns false.
+func walk(err error, fn func(err error) bool) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if !fn(err) {
+			return false
+		}
+
+		if multi, ok := err.(multiError); ok {
+			for _, branch := range multi.Unwrap() {
+				if !walk(branch, fn) {
+					return false
+				}
+			}
+
+			return true
+		}
+	}
+
+	return true
+}
+
+// findMulti - returns the first multi-error in the chain of err followed with errors.Unwrap, nil if there's none.
+func findMulti(err error) multiError {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if multi, ok := err.(multiError); ok {
+			return multi
+		}
+	}
+
+	return nil
+}
+
+// writeBranches - writes header of error err and reports of branches of multi as a numbered list.
+func writeBranches(w io.Writer, p palette, err error, multi multiError) {
+	branches := multi.Unwrap()
+
+	if err == error(multi) {
+		fmt.Fprintf(w, "errors (%d):\n", len(branches))
+	} else {
+		fmt.Fprintf(w, "error: %s\n", p.paint(ansiRed, truncateMessage(Message(err))))
+
+		if frame := topFrame(err); frame != (Frame{}) {
+			fmt.Fprintf(w, "caller: %s\n", strings.TrimSuffix(frame.caller(), ": "))
+		}
+
+		fmt.Fprintf(w, "errors (%d):\n", len(branches))
+	}
+
+	for i, branch := range branches {
+		var b strings.Builder
+
+		writeReport(&b, p, branch)
+
+		prefix := fmt.Sprintf("%d. ", i+1)
+		indent := strings.Repeat(" ", len(prefix))
+
+		for j, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+			if j == 0 {
+				fmt.Fprintf(w, "%s%s\n", prefix, line)
+			} else {
+				fmt.Fprintf(w, "%s%s\n", indent, line)
+			}
+		}
+	}
+}
+
+// jsonBranches - returns JSON representations of branches of the first multi-error in the chain of err.
+func jsonBranches(err error) []json.RawMessage {
+	multi := findMulti(err)
+	if multi == nil {
+		return nil
+	}
+
+	branches := multi.Unwrap()
+	out := make([]json.RawMessage, 0, len(branches))
+
+	for _, branch := range branches {
+		data, jsonErr := MarshalError(branch)
+		if jsonErr != nil {
+			data, _ = json.Marshal(jsonError{Message: Message(branch)})
+		}
+
+		out = append(out, data)
+	}
+
+	return out
+}
+
+// Flatten - returns leaves of the error tree of err: branches of multi-errors (e.g. errors.Join) are expanded
+// recursively, other errors are returned as is. Nil errors are skipped.
+func Flatten(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	multi, ok := err.(multiError)
+	if !ok {
+		return []error{err}
+	}
+
+	var flat []error
+	for _, branch := range multi.Unwrap() {
+		flat = append(flat, Flatten(branch)...)
+	}
+
+	return flat
+}
+
+// Dedupe - returns errs without nil errors and errors with the same Fingerprint as a preceding one.
+func Dedupe(errs []error) []error {
+	var (
+		seen   = make(map[string]struct{}, len(errs))
+		unique = make([]error, 0, len(errs))
+	)
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		key := Fingerprint(err)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		unique = append(unique, err)
+	}
+
+	return unique
+}
@@ -0,0 +1,132 @@
+package lazyerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestMultiFormat(t *testing.T) {
+	joined := errors.Join(
+		testWrapper(Try, Catch, testFuncError),
+		testWrapper(TryWrapStackFunc, Catch, testFuncError),
+		testWrapper(Try, Catch, testFuncPanic),
+	)
+
+	report := fmt.Sprintf("%+v", Detailed(joined))
+	for _, expected := range []string{"errors (3):\n1. error: test error\n   caller: ", "\n2. error: test error\n   stack:\n", "\n3. panic: test panic\n   stack:\n"} {
+		if !strings.Contains(report, expected) {
+			t.Fatal("unexpected:", report)
+		}
+	}
+
+	fmt.Println(report)
+
+	wrapped := testWrapper(Try, Catch, func() error { return joined })
+	if report := fmt.Sprintf("%+v", wrapped); !strings.Contains(report, "caller: ") || !strings.Contains(report, "errors (3):\n") {
+		t.Fatal("unexpected:", report)
+	}
+
+	if fmt.Sprintf("%v", wrapped) != wrapped.Error() || fmt.Sprintf("%s", Detailed(joined)) != joined.Error() {
+		t.Fatal("unexpected:", wrapped)
+	}
+}
+
+func TestMultiJSON(t *testing.T) {
+	joined := errors.Join(testWrapper(Try, Catch, testFuncError), testWrapper(Try, Catch, testFuncPanic))
+
+	for _, err := range []error{joined, testWrapper(Try, Catch, func() error { return joined })} {
+		data, jsonErr := MarshalError(err)
+		if jsonErr != nil {
+			t.Fatal("unexpected:", jsonErr)
+		}
+
+		var decoded struct {
+			Errors []map[string]interface{} `json:"errors"`
+		}
+
+		if jsonErr := json.Unmarshal(data, &decoded); jsonErr != nil || len(decoded.Errors) != 2 || (callerEnabled && decoded.Errors[0]["caller"] == nil) || decoded.Errors[1]["recovered"] != "test panic" {
+			t.Fatal("unexpected:", string(data), jsonErr)
+		} else {
+			fmt.Println(string(data))
+		}
+	}
+}
+
+func TestMarshalWrapped(t *testing.T) {
+	var decoded map[string]interface{}
+
+	data, jsonErr := MarshalError(<-Go(func() { panic("p") }))
+	if jsonErr != nil || json.Unmarshal(data, &decoded) != nil || decoded["recovered"] != "p" || decoded["frames"] == nil {
+		t.Fatal("unexpected:", string(data), jsonErr)
+	}
+
+	decoded = nil
+	wrapped := fmt.Errorf("ctx: %w", With(testWrapper(TryWrapStackFunc, CatchAllFunc, testFuncError), "id", 1))
+
+	data, jsonErr = MarshalError(wrapped)
+	if jsonErr != nil || json.Unmarshal(data, &decoded) != nil || decoded["message"] != "ctx: test error" || decoded["frames"] == nil || decoded["attrs"] == nil {
+		t.Fatal("unexpected:", string(data), jsonErr)
+	} else {
+		fmt.Println(string(data))
+	}
+}
+
+func TestMultiUnwrap(t *testing.T) {
+	var (
+		errDomain = errors.New("domain error")
+		inner     = testWrapper(Try, Catch, func() error { return With(fmt.Errorf("load: %w", io.EOF), "id", 1) })
+		panicked  = testWrapper(Try, Catch, func() error { var m map[string]int; m["key"] = 1; return nil })
+		joined    = errors.Join(inner, fmt.Errorf("%w: %w", errDomain, panicked))
+		err       = testWrapper(Try, Catch, func() error { return With(joined, "batch", 2) })
+	)
+
+	for _, target := range []error{io.EOF, errDomain, ErrPanic} {
+		if !errors.Is(err, target) {
+			t.Fatal("unexpected: not", target, err)
+		}
+	}
+
+	var (
+		withCaller *LazyErrorWithCaller
+		fromPanic  *LazyErrorFromPanic
+		runtimeErr runtime.Error
+	)
+
+	if !errors.As(err, &withCaller) || withCaller.Err == nil || !errors.As(err, &fromPanic) || !errors.As(err, &runtimeErr) {
+		t.Fatal("unexpected:", err)
+	}
+
+	if attrs := Attrs(err); len(attrs) != 2 || attrs[0].Key != "batch" || attrs[1].Key != "id" {
+		t.Fatal("unexpected:", attrs)
+	} else {
+		fmt.Println(attrs, fromPanic.Kind)
+	}
+}
+
+func TestFlattenDedupe(t *testing.T) {
+	var (
+		a = testWrapper(Try, Catch, func() error { return fmt.Errorf("user %d not found", 1) })
+		b = testWrapper(Try, Catch, func() error { return fmt.Errorf("user %d not found", 2) })
+		c = testWrapper(Try, Catch, testFuncPanic)
+	)
+
+	flat := Flatten(errors.Join(a, errors.Join(b, nil, c), io.EOF))
+	if len(flat) != 4 || flat[0] != a || flat[1] != b || flat[2] != c || flat[3] != io.EOF {
+		t.Fatal("unexpected:", flat)
+	}
+
+	if unique := Dedupe(append(flat, nil, io.EOF)); len(unique) != 3 || unique[0] != a || unique[1] != c || unique[2] != io.EOF {
+		t.Fatal("unexpected:", unique)
+	} else {
+		fmt.Println(unique)
+	}
+
+	if Flatten(nil) != nil || len(Flatten(io.EOF)) != 1 {
+		t.Fatal("unexpected:", Flatten(nil))
+	}
+}
@@ -0,0 +1,6 @@
+//go:build lazyerrors_nocaller
+
+package lazyerrors
+
+// callerEnabled - caller capture is compiled out by lazyerrors_nocaller tag.
+const callerEnabled = false
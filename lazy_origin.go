@@ -0,0 +1,108 @@
+package lazyerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+type (
+	// Origin - stack of the place where a goroutine was started, see NewOrigin.
+	Origin struct {
+		callers []uintptr
+	}
+	// LazyErrorWithOrigin - custom error structure that contains the stack of the place where the goroutine was started.
+	LazyErrorWithOrigin struct {
+		Err    error
+		Origin *Origin
+	}
+)
+
+// Error - error interface implementation.
+func (e *LazyErrorWithOrigin) Error() string {
+	return redact(fmt.Sprintf("%v\n[origin]:\n%s", e.Err, formatFrames(e.Origin.Frames())))
+}
+
+// Unwrap - error interface implementation.
+func (e *LazyErrorWithOrigin) Unwrap() error {
+	return e.Err
+}
+
+// Is - error interface implementation.
+func (e *LazyErrorWithOrigin) Is(err error) bool {
+	return errors.Is(e.Err, err)
+}
+
+// Format - fmt.Formatter interface implementation, %+v writes the report in the same way as WriteReport does.
+func (e *LazyErrorWithOrigin) Format(s fmt.State, verb rune) {
+	format(s, verb, e)
+}
+
+// MarshalJSON - json.Marshaler interface implementation.
+func (e *LazyErrorWithOrigin) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toJSON())
+}
+
+// toJSON - returns JSON representation of the wrapped error with the origin stack.
+func (e *LazyErrorWithOrigin) toJSON() jsonError {
+	out, ok := lazyJSON(e.Err)
+	if !ok {
+		out = jsonError{
+			Message: truncateMessage(Message(e.Err)),
+			Attrs:   attrsMap(Attrs(e.Err)),
+			Chain:   chain(e.Err),
+			Errors:  jsonBranches(e.Err),
+		}
+	}
+
+	out.Origin = newJSONFrames(e.Origin.Frames())
+
+	return out
+}
+
+// NewOrigin - captures the current stack, call it before starting a goroutine and use the result inside of it.
+//
+//	origin := lazyerrors.NewOrigin()
+//
+//	go func() {
+//	        var err error
+//
+//	        defer func() { report(err) }()
+//	        defer origin.Catch(&err)
+//	        lazyerrors.Try(bar())
+//	}()
+func NewOrigin() *Origin {
+	return &Origin{callers: callers()}
+}
+
+// Frames - returns structured frames of the origin stack.
+func (o *Origin) Frames() []Frame {
+	if o == nil {
+		return nil
+	}
+
+	return resolveFrames(o.callers)
+}
+
+// Wrap - wraps non-nil error err into LazyErrorWithOrigin.
+func (o *Origin) Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &LazyErrorWithOrigin{
+		Err:    err,
+		Origin: o,
+	}
+}
+
+// Catch - catches thrown error or panic like CatchAllWithStackFunc and wraps it into LazyErrorWithOrigin.
+func (o *Origin) Catch(ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		*ep = o.Wrap(recoveredError(r))
+	}
+}
@@ -0,0 +1,49 @@
+package lazyerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestOrigin(t *testing.T) {
+	origin := NewOrigin()
+	errs := make(chan error)
+
+	go func() {
+		var err error
+
+		defer func() { errs <- err }()
+		defer origin.Catch(&err)
+		Try(testFuncPanic())
+	}()
+
+	err := <-errs
+
+	var originErr *LazyErrorWithOrigin
+
+	if !errors.As(err, &originErr) || !errors.Is(err, ErrPanic) || !strings.Contains(err.Error(), "TestOrigin(...)") {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	if report := fmt.Sprintf("%+v", err); !strings.Contains(report, "\norigin:\n") || !strings.Contains(report, "TestOrigin(...)") {
+		t.Fatal("unexpected:", report)
+	}
+
+	var decoded struct {
+		Recovered string                   `json:"recovered"`
+		Origin    []map[string]interface{} `json:"origin"`
+	}
+
+	if data, jsonErr := MarshalError(err); jsonErr != nil || json.Unmarshal(data, &decoded) != nil || decoded.Recovered != "test panic" || len(decoded.Origin) == 0 || decoded.Origin[0]["function"] != packagePrefix+"TestOrigin" {
+		t.Fatal("unexpected:", string(data), jsonErr)
+	}
+
+	if origin.Wrap(nil) != nil {
+		t.Fatal("unexpected wrap")
+	}
+}
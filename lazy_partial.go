@@ -0,0 +1,51 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Partial - result of a best-effort batch operation: successfully produced values and joined failures.
+type Partial[T any] struct {
+	Values []T
+	Err    error
+}
+
+// PartialSlice - maps in with fn like TrySlice, but doesn't stop on failures: every call is run under Catch,
+// values of successful calls are kept in order, errors are annotated with the element index and joined.
+func PartialSlice[T, U any](in []T, fn func(T) (U, error)) Partial[U] {
+	var (
+		out  = make([]U, 0, len(in))
+		errs []error
+	)
+
+	for i, v := range in {
+		u, err := Do1(func() U { return Try1(fn(v)) })
+		if err != nil {
+			errs = append(errs, fmt.Errorf("index %d: %w", i, err))
+
+			continue
+		}
+
+		out = append(out, u)
+	}
+
+	return Partial[U]{Values: out, Err: errors.Join(errs...)}
+}
+
+// Unpack - returns the values and the error of Partial.
+func (p Partial[T]) Unpack() ([]T, error) {
+	return p.Values, p.Err
+}
+
+// Get - checks the error of Partial with Try and returns its values, so it's all or nothing inside of a Catch scope.
+func (p Partial[T]) Get() []T {
+	Try(p.Err)
+
+	return p.Values
+}
+
+// Failed - reports whether any element of the batch failed.
+func (p Partial[T]) Failed() bool {
+	return p.Err != nil
+}
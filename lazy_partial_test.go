@@ -0,0 +1,38 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPartialSlice(t *testing.T) {
+	p := PartialSlice([]string{"1", "x", "3", ""}, func(s string) (int, error) {
+		if s == "" {
+			panic("empty")
+		}
+
+		return strconv.Atoi(s)
+	})
+
+	values, err := p.Unpack()
+	if len(values) != 2 || values[1] != 3 || !p.Failed() || !errors.Is(err, strconv.ErrSyntax) || !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", values, err)
+	}
+
+	if !strings.Contains(err.Error(), "index 1: ") || !strings.Contains(err.Error(), "index 3: ") {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(values, len(Flatten(err)))
+	}
+
+	if _, err := Do1(p.Get); err == nil {
+		t.Fatal("unexpected: nil error")
+	}
+
+	if ok := PartialSlice([]int{1}, func(i int) (int, error) { return i, nil }); ok.Failed() || len(ok.Get()) != 1 {
+		t.Fatal("unexpected:", ok)
+	}
+}
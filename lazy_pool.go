@@ -0,0 +1,121 @@
+package lazyerrors
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrPoolDrained - error returned by Pool.Submit after Drain was called.
+var ErrPoolDrained = errors.New("pool is drained")
+
+// Pool - fixed number of workers running submitted tasks under Catch.
+//
+// A panic inside of a task never kills a worker, even if Catch is configured to continue panicking.
+type Pool struct {
+	tasks   chan func() error
+	done    chan struct{}
+	workers sync.WaitGroup
+	mu      sync.RWMutex
+	drained bool
+	onError func(err error)
+	errsMu  sync.Mutex
+	errs    []error
+}
+
+// NewPool - starts a pool of n workers (at least one).
+func NewPool(n int) *Pool {
+	p := &Pool{tasks: make(chan func() error), done: make(chan struct{})}
+
+	for i := 0; i < max(n, 1); i++ {
+		p.workers.Add(1)
+
+		go p.work()
+	}
+
+	return p
+}
+
+// OnError - sets callback fn receiving errors of failed tasks instead of Drain, it must be called before Submit.
+//
+// Fn is called from worker goroutines concurrently.
+func (p *Pool) OnError(fn func(err error)) *Pool {
+	p.onError = fn
+
+	return p
+}
+
+// Submit - passes task to an idle worker, blocks until there's one. ErrPoolDrained is returned after Drain.
+//
+// Tasks may submit other tasks, a Submit blocked on busy workers is released by Drain with ErrPoolDrained.
+func (p *Pool) Submit(task func() error) error {
+	p.mu.RLock()
+	drained := p.drained
+	p.mu.RUnlock()
+
+	if drained {
+		return ErrPoolDrained
+	}
+
+	select {
+	case p.tasks <- task:
+		return nil
+	case <-p.done:
+		return ErrPoolDrained
+	}
+}
+
+// Drain - stops accepting tasks, waits for the submitted ones and returns errors.Join of their failures.
+//
+// Nil is returned if errors were passed to the OnError callback.
+func (p *Pool) Drain() error {
+	p.mu.Lock()
+	if !p.drained {
+		p.drained = true
+		close(p.done)
+	}
+	p.mu.Unlock()
+
+	p.workers.Wait()
+
+	p.errsMu.Lock()
+	defer p.errsMu.Unlock()
+
+	return errors.Join(p.errs...)
+}
+
+// work - runs tasks until the pool is drained.
+func (p *Pool) work() {
+	defer p.workers.Done()
+
+	for {
+		select {
+		case task := <-p.tasks:
+			if err := runSafe(task); err != nil {
+				p.fail(err)
+			}
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// fail - passes error err of a task to OnError callback or keeps it for Drain.
+func (p *Pool) fail(err error) {
+	if p.onError != nil {
+		p.onError(err)
+
+		return
+	}
+
+	p.errsMu.Lock()
+	defer p.errsMu.Unlock()
+
+	p.errs = append(p.errs, err)
+}
+
+// runSafe - runs fn under Catch like run, panics escaping from Catch are caught with CatchAllWithStackFunc.
+func runSafe(fn func() error) (err error) {
+	defer CatchAllWithStackFunc(&err)
+
+	return run(fn)
+}
@@ -0,0 +1,94 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	p := NewPool(2)
+
+	for _, f := range []func() error{testFuncNoError, testFuncError, testFuncPanic, testFuncError} {
+		if err := p.Submit(f); err != nil {
+			t.Fatal("unexpected:", err)
+		}
+	}
+
+	err := p.Drain()
+	if errs := err.(interface{ Unwrap() []error }).Unwrap(); len(errs) != 3 || !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	if err := p.Submit(testFuncNoError); !errors.Is(err, ErrPoolDrained) {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := p.Drain(); err == nil {
+		t.Fatal("unexpected: nil error")
+	}
+}
+
+func TestPoolOnError(t *testing.T) {
+	defer SetDefaultCatch(nil)
+
+	// panics escaping from Catch don't kill workers.
+	SetDefaultCatch(CatchErrorHandler)
+
+	var (
+		mu     sync.Mutex
+		failed []error
+	)
+
+	p := NewPool(1).OnError(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		failed = append(failed, err)
+	})
+
+	for _, f := range []func() error{testFuncPanic, testFuncPanic, testFuncError} {
+		_ = p.Submit(f)
+	}
+
+	if err := p.Drain(); err != nil || len(failed) != 3 || !errors.Is(failed[0], ErrPanic) {
+		t.Fatal("unexpected:", err, failed)
+	}
+}
+
+func TestPoolFanOut(t *testing.T) {
+	p := NewPool(1)
+
+	started, submitted := make(chan struct{}), make(chan error, 1)
+
+	// the only worker is busy, so the nested Submit blocks until Drain.
+	_ = p.Submit(func() error {
+		close(started)
+		submitted <- p.Submit(testFuncNoError)
+
+		return nil
+	})
+
+	<-started
+
+	drained := make(chan error, 1)
+
+	go func() { drained <- p.Drain() }()
+
+	select {
+	case err := <-drained:
+		if err != nil {
+			t.Fatal("unexpected:", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("unexpected: Drain is blocked")
+	}
+
+	if err := <-submitted; !errors.Is(err, ErrPoolDrained) {
+		t.Fatal("unexpected:", err)
+	}
+}
@@ -0,0 +1,60 @@
+package lazyerrors
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// Profile label keys set by CatchProfiled and ProfileScope.
+const (
+	// LabelSite - pprof label key of the function where a caught error was wrapped or a panic was recovered.
+	LabelSite = "lazyerrors_site"
+	// LabelFingerprint - pprof label key of Fingerprint of a caught error.
+	LabelFingerprint = "lazyerrors_fingerprint"
+	// LabelScope - pprof label key of a scope name given to ProfileScope.
+	LabelScope = "lazyerrors_scope"
+)
+
+// CatchProfiled - catches thrown error or panic like CatchAllWithStackFunc, OnCatch hooks are run under pprof labels
+// of ctx extended with LabelSite and LabelFingerprint of the caught error.
+//
+// It shows the time spent in error handling (logging, reporting, etc.) per failing site in CPU profiles.
+// Goroutine labels are restored to the labels of ctx afterwards.
+func CatchProfiled(ctx context.Context, ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		err := toError(r)
+		*ep = err
+
+		pprof.Do(ctx, ProfileLabels(err), func(context.Context) {
+			notifyCatch(err, isPanic(r))
+		})
+	}
+}
+
+// ProfileLabels - returns pprof labels of error err: LabelSite (if known) and LabelFingerprint.
+func ProfileLabels(err error) pprof.LabelSet {
+	if frame := topFrame(err); frame.Function != "" {
+		return pprof.Labels(LabelSite, frame.ShortFunction(), LabelFingerprint, Fingerprint(err))
+	}
+
+	return pprof.Labels(LabelFingerprint, Fingerprint(err))
+}
+
+// ProfileScope - runs fn under pprof labels of ctx extended with LabelScope set to name.
+//
+// The scope is kept in labels set by CatchProfiled with the context passed to fn.
+func ProfileScope(ctx context.Context, name string, fn func(ctx context.Context)) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	pprof.Do(ctx, pprof.Labels(LabelScope, name), fn)
+}
@@ -0,0 +1,50 @@
+package lazyerrors
+
+import (
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"strings"
+	"testing"
+)
+
+func TestCatchProfiled(t *testing.T) {
+	defer ResetHooks()
+
+	var profiles []string
+
+	// goroutine labels can't be read directly, so they're checked in the goroutine profile.
+	RegisterOnCatch(func(error, bool) {
+		var b strings.Builder
+
+		_ = pprof.Lookup("goroutine").WriteTo(&b, 1)
+		profiles = append(profiles, b.String())
+	})
+
+	for _, f := range []func() error{testFuncError, testFuncPanic} {
+		var err error
+
+		ProfileScope(context.Background(), "test", func(ctx context.Context) {
+			defer CatchProfiled(ctx, &err)
+			Try(f())
+		})
+
+		if err == nil {
+			t.Fatal("unexpected: nil error")
+		}
+
+		fmt.Println(ProfileLabels(err))
+	}
+
+	if len(profiles) != 2 {
+		t.Fatal("unexpected:", len(profiles))
+	}
+
+	for _, profile := range profiles {
+		for _, label := range []string{`"lazyerrors_scope":"test"`, `"lazyerrors_fingerprint":`, `"lazyerrors_site":`} {
+			if (callerEnabled || label != `"lazyerrors_site":`) && !strings.Contains(profile, label) {
+				t.Fatal("unexpected:", profile)
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package lazyerrors
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// RecentError - caught error recorded in the ring of recent errors (see KeepRecent).
+	RecentError struct {
+		Time        time.Time `json:"time"`
+		Fingerprint string    `json:"fingerprint"`
+		Caller      string    `json:"caller,omitempty"`
+		Goroutine   uint64    `json:"goroutine"`
+		Recovered   bool      `json:"recovered"`
+		Message     string    `json:"message"`
+		Err         error     `json:"-"`
+	}
+	// recentRing - bounded ring of the last caught errors.
+	recentRing struct {
+		mu      sync.Mutex
+		enabled atomic.Bool
+		entries []RecentError
+		next    int
+		full    bool
+	}
+)
+
+// recent - ring of recent errors fed by built-in catch handlers, disabled until KeepRecent is called.
+var recent recentRing
+
+// KeepRecent - keeps the last n errors caught by built-in catch handlers in memory, 0 disables it (default).
+//
+// Previously recorded errors are dropped.
+func KeepRecent(n int) {
+	recent.mu.Lock()
+	defer recent.mu.Unlock()
+
+	recent.entries = make([]RecentError, max(n, 0))
+	recent.next, recent.full = 0, false
+	recent.enabled.Store(n > 0)
+}
+
+// Recent - returns the recorded recent errors, the latest first.
+func Recent() []RecentError {
+	recent.mu.Lock()
+	defer recent.mu.Unlock()
+
+	n := recent.next
+	if recent.full {
+		n = len(recent.entries)
+	}
+
+	out := make([]RecentError, 0, n)
+	for i := 1; i <= n; i++ {
+		out = append(out, recent.entries[(recent.next-i+len(recent.entries))%len(recent.entries)])
+	}
+
+	return out
+}
+
+// RecentHandler - returns http.Handler that writes the recorded recent errors as JSON, the latest first.
+func RecentHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(Recent())
+	})
+}
+
+// add - records caught error err if the ring is enabled.
+func (r *recentRing) add(err error, recovered bool) {
+	if !r.enabled.Load() {
+		return
+	}
+
+	entry := RecentError{
+		Time:        time.Now(),
+		Fingerprint: Fingerprint(err),
+		Caller:      strings.TrimSuffix(topFrame(err).caller(), ": "),
+		Goroutine:   goroutineID(),
+		Recovered:   recovered,
+		Message:     Message(err),
+		Err:         err,
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.entries) == 0 {
+		return
+	}
+
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % len(r.entries)
+	r.full = r.full || r.next == 0
+}
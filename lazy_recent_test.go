@@ -0,0 +1,40 @@
+package lazyerrors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestKeepRecent(t *testing.T) {
+	defer KeepRecent(0)
+
+	_ = testWrapper(Try, Catch, testFuncError)
+
+	if len(Recent()) != 0 {
+		t.Fatal("unexpected:", Recent())
+	}
+
+	KeepRecent(2)
+
+	for _, f := range []func() error{testFuncError, testFuncNoError, testFuncPanic, testFuncError} {
+		_ = testWrapper(Try, Catch, f)
+	}
+
+	r := Recent()
+	if len(r) != 2 || r[0].Recovered || !r[1].Recovered || (callerEnabled && r[0].Caller == "") || r[0].Goroutine == 0 || r[1].Message != "test panic" {
+		t.Fatal("unexpected:", r)
+	}
+
+	rec := httptest.NewRecorder()
+	RecentHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/errors", nil))
+
+	var dumped []RecentError
+
+	if err := json.Unmarshal(rec.Body.Bytes(), &dumped); err != nil || len(dumped) != 2 || dumped[0].Fingerprint != r[0].Fingerprint {
+		t.Fatal("unexpected:", rec.Body.String(), err)
+	} else {
+		fmt.Println(rec.Body.String())
+	}
+}
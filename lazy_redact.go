@@ -0,0 +1,46 @@
+package lazyerrors
+
+import "regexp"
+
+type (
+	// Redactor - masks sensitive data in messages and stacks before they are formatted.
+	Redactor interface {
+		Redact(s string) string
+	}
+	// RedactorFunc - function adapter of Redactor.
+	RedactorFunc func(s string) string
+)
+
+// redactors - registered redactors.
+var redactors registry[Redactor]
+
+// Redact - Redactor interface implementation.
+func (f RedactorFunc) Redact(s string) string {
+	return f(s)
+}
+
+// RegisterRedactor - registers redactor r, redactors are applied in order of registration.
+func RegisterRedactor(r Redactor) {
+	redactors.add(r)
+}
+
+// ResetRedactors - removes all registered redactors.
+func ResetRedactors() {
+	redactors.reset()
+}
+
+// RedactRegexp - returns Redactor that replaces matches of re with repl (see regexp.Regexp.ReplaceAllString).
+func RedactRegexp(re *regexp.Regexp, repl string) Redactor {
+	return RedactorFunc(func(s string) string {
+		return re.ReplaceAllString(s, repl)
+	})
+}
+
+// redact - applies registered redactors to s.
+func redact(s string) string {
+	for _, r := range redactors.load() {
+		s = r.Redact(s)
+	}
+
+	return s
+}
@@ -0,0 +1,39 @@
+package lazyerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	defer ResetRedactors()
+
+	RegisterRedactor(RedactRegexp(regexp.MustCompile(`token=\w+`), "token=***"))
+	RegisterRedactor(RedactorFunc(func(s string) string { return strings.ReplaceAll(s, "hunter2", "***") }))
+
+	secret := func() error { return errors.New("token=abc password=hunter2") }
+
+	for _, err := range []error{
+		testWrapper(TryWrapErrorFunc, CatchAllFunc, secret),
+		testWrapper(TryWrapStackFunc, CatchAllFunc, secret),
+		testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, func() error { panic("token=abc") }),
+	} {
+		var b strings.Builder
+
+		WriteReport(&b, err)
+
+		data, _ := json.Marshal(err)
+
+		for _, s := range []string{err.Error(), Message(err), b.String(), string(data)} {
+			if strings.Contains(s, "abc") || strings.Contains(s, "hunter2") {
+				t.Fatal("unexpected:", s)
+			}
+		}
+
+		fmt.Println(Message(err))
+	}
+}
@@ -0,0 +1,40 @@
+package lazyerrors
+
+import "sync/atomic"
+
+// registry - copy-on-write list of registered items, safe for concurrent use.
+type registry[T any] struct {
+	items atomic.Pointer[[]T]
+}
+
+// add - appends item to the registry.
+func (r *registry[T]) add(item T) {
+	for {
+		old := r.items.Load()
+
+		var list []T
+		if old != nil {
+			list = append(list, *old...)
+		}
+
+		list = append(list, item)
+
+		if r.items.CompareAndSwap(old, &list) {
+			return
+		}
+	}
+}
+
+// load - returns registered items, the result must not be modified.
+func (r *registry[T]) load() []T {
+	if list := r.items.Load(); list != nil {
+		return *list
+	}
+
+	return nil
+}
+
+// reset - removes all registered items.
+func (r *registry[T]) reset() {
+	r.items.Store(nil)
+}
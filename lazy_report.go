@@ -0,0 +1,166 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// ColorMode - mode of ANSI coloring of reports written by WriteReport.
+type ColorMode uint8
+
+const (
+	// ColorAuto - colors are used when the writer is a terminal and NO_COLOR isn't set.
+	ColorAuto ColorMode = iota
+	// ColorAlways - colors are always used.
+	ColorAlways
+	// ColorNever - colors are never used.
+	ColorNever
+)
+
+// ANSI escape sequences used in colored reports.
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiDim   = "\x1b[2m"
+	ansiRed   = "\x1b[1;31m"
+)
+
+// color - coloring mode of reports, set with SetColor.
+var color atomic.Uint32
+
+// SetColor - sets coloring mode of reports, defaults to ColorAuto.
+func SetColor(mode ColorMode) {
+	color.Store(uint32(mode))
+}
+
+// WriteReport - writes message, caller, stack and hops (if present) of error err to w, colored according to SetColor.
+func WriteReport(w io.Writer, err error) {
+	var (
+		p = newPalette(w)
+		b strings.Builder
+	)
+	// write redacted report at once.
+	defer func() { io.WriteString(w, redact(b.String())) }()
+
+	writeReport(&b, p, err)
+}
+
+// writeReport - writes report of error err to w, branches of multi-errors are written as a numbered list.
+func writeReport(w io.Writer, p palette, err error) {
+	var (
+		withCaller *LazyErrorWithCaller
+		withStack  *LazyErrorWithStack
+		fromPanic  *LazyErrorFromPanic
+		withOrigin *LazyErrorWithOrigin
+	)
+
+	if multi := findMulti(err); multi != nil {
+		writeBranches(w, p, err, multi)
+
+		return
+	}
+
+	switch {
+	case errors.As(err, &fromPanic):
+		if fromPanic.Kind != PanicValue {
+			fmt.Fprintf(w, "panic (%s): %s\n", fromPanic.Kind, p.paint(ansiRed, fromPanic.recovered()))
+		} else {
+			fmt.Fprintf(w, "panic: %s\n", p.paint(ansiRed, fromPanic.recovered()))
+		}
+
+		if fromPanic.Stack != "" {
+			fmt.Fprintf(w, "stack:\n%s\n", strings.TrimSpace(truncateStack(fromPanic.Stack)))
+		} else {
+			fmt.Fprintf(w, "stack:\n%s", truncateStack(p.frames(fromPanic.Frames())))
+		}
+	case errors.As(err, &withStack):
+		fmt.Fprintf(w, "error: %s\n", p.paint(ansiRed, truncateMessage(Message(err))))
+		fmt.Fprintf(w, "stack:\n%s", truncateStack(p.frames(withStack.Frames())))
+	case errors.As(err, &withCaller):
+		fmt.Fprintf(w, "error: %s\n", p.paint(ansiRed, truncateMessage(Message(err))))
+		fmt.Fprintf(w, "caller: %s\n", strings.TrimSuffix(withCaller.Caller, ": "))
+	default:
+		fmt.Fprintf(w, "error: %s\n", p.paint(ansiRed, truncateMessage(err.Error())))
+	}
+
+	if hops := hopsOf(err); len(hops) > 0 {
+		fmt.Fprintf(w, "hops:\n")
+
+		for _, hop := range hops {
+			fmt.Fprintf(w, "\t%s\n", strings.TrimSuffix(hop.caller(), ": "))
+		}
+	}
+
+	if errors.As(err, &withOrigin) {
+		fmt.Fprintf(w, "origin:\n%s", truncateStack(p.frames(withOrigin.Origin.Frames())))
+	}
+}
+
+// IsTerminal - reports whether w is a terminal (character device).
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// palette - paints parts of a report, does nothing if colors are disabled.
+type palette bool
+
+// newPalette - returns palette for writer w according to SetColor.
+func newPalette(w io.Writer) palette {
+	switch ColorMode(color.Load()) {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	default:
+		return palette(os.Getenv("NO_COLOR") == "" && IsTerminal(w))
+	}
+}
+
+// paint - wraps s into ANSI escape sequence code.
+func (p palette) paint(code, s string) string {
+	if !p {
+		return s
+	}
+
+	return code + s + ansiReset
+}
+
+// frames - formats frames like formatFrames does, application frames are bold and standard library frames are dim.
+func (p palette) frames(frames []Frame) string {
+	if !p {
+		return formatFrames(frames)
+	}
+
+	var b strings.Builder
+
+	for _, frame := range frames {
+		code := ansiBold
+		if isStandardFrame(frame) {
+			code = ansiDim
+		}
+
+		fmt.Fprintf(&b, "%s\n\t%s\n", p.paint(code, frame.Function+"(...)"), p.paint(ansiDim, fmt.Sprintf("%s:%d", frame.file(), frame.Line)))
+	}
+
+	return b.String()
+}
+
+// isStandardFrame - reports whether frame belongs to runtime or standard library (no dot in the first path element).
+func isStandardFrame(frame Frame) bool {
+	if i := strings.Index(frame.Function, "/"); i >= 0 {
+		return !strings.Contains(frame.Function[:i], ".")
+	}
+
+	return frame.Function != "" && !strings.HasPrefix(frame.Function, "main.")
+}
@@ -0,0 +1,70 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWriteReport(t *testing.T) {
+	defer SetColor(ColorAuto)
+
+	for _, mode := range []ColorMode{ColorAuto, ColorAlways} {
+		SetColor(mode)
+
+		var buf strings.Builder
+
+		for _, err := range []error{
+			testWrapper(TryWrapErrorFunc, CatchAllFunc, testFuncError),
+			testWrapper(TryWrapStackFunc, CatchAllFunc, testFuncError),
+			testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, testFuncPanic),
+		} {
+			WriteReport(&buf, err)
+		}
+
+		if colored := strings.Contains(buf.String(), ansiReset); colored != (mode == ColorAlways) {
+			t.Fatal("unexpected:", buf.String())
+		} else {
+			fmt.Print(buf.String())
+		}
+	}
+}
+
+func TestWriteReportWrapped(t *testing.T) {
+	for _, try := range []func(error){TryWrapErrorFunc, TryWrapStackFunc} {
+		err := func() (err error) {
+			defer CatchWrap(&err, "loading user %d", 42)
+			try(errors.New("boom"))
+
+			return nil
+		}()
+
+		var buf strings.Builder
+
+		WriteReport(&buf, err)
+
+		if report := buf.String(); !strings.HasPrefix(report, "error: loading user 42: boom\n") {
+			t.Fatal("unexpected:", report)
+		} else if formatted := fmt.Sprintf("%+v", NewErrorWithCaller(err)); !strings.HasPrefix(formatted, "error: loading user 42: boom\n") {
+			t.Fatal("unexpected:", formatted)
+		}
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "report")
+	if err != nil {
+		t.Fatal("unexpected:", err)
+	}
+	defer f.Close()
+
+	if IsTerminal(f) || IsTerminal(&strings.Builder{}) {
+		t.Fatal("unexpected terminal")
+	}
+
+	if !isStandardFrame(Frame{Function: "testing.tRunner"}) || isStandardFrame(Frame{Function: "main.main"}) || isStandardFrame(Frame{Function: packagePrefix + "Do"}) {
+		t.Fatal("unexpected frame classification")
+	}
+}
@@ -0,0 +1,32 @@
+package lazyerrors
+
+import "errors"
+
+// Reporter - forwarder of caught errors and recovered panics to an error tracking service.
+type Reporter interface {
+	// CaptureError - reports caught error err.
+	CaptureError(err error)
+	// CapturePanic - reports recovered panic err with the stack captured at the panic site.
+	CapturePanic(err *LazyErrorFromPanic)
+}
+
+// RegisterReporter - registers OnCatch hook that forwards caught errors and recovered panics to r.
+//
+// Errors aren't forwarded while Storming.
+func RegisterReporter(r Reporter) {
+	RegisterOnCatch(func(err error, recovered bool) {
+		if downgrade() {
+			return
+		}
+
+		var panicErr *LazyErrorFromPanic
+
+		if recovered && errors.As(err, &panicErr) {
+			r.CapturePanic(panicErr)
+
+			return
+		}
+
+		r.CaptureError(err)
+	})
+}
@@ -0,0 +1,37 @@
+package lazyerrors
+
+import (
+	"fmt"
+	"testing"
+)
+
+type testReporter struct {
+	errors []error
+	panics []*LazyErrorFromPanic
+}
+
+func (r *testReporter) CaptureError(err error) {
+	r.errors = append(r.errors, err)
+}
+
+func (r *testReporter) CapturePanic(err *LazyErrorFromPanic) {
+	r.panics = append(r.panics, err)
+}
+
+func TestRegisterReporter(t *testing.T) {
+	defer ResetHooks()
+
+	r := &testReporter{}
+
+	RegisterReporter(r)
+
+	for _, f := range []func() error{testFuncNoError, testFuncError, testFuncPanic} {
+		_ = testWrapper(Try, Catch, f)
+	}
+
+	if len(r.errors) != 1 || len(r.panics) != 1 || len(r.panics[0].Frames()) == 0 {
+		t.Fatal("unexpected:", r.errors, r.panics)
+	} else {
+		fmt.Println(r.errors, r.panics[0].Frames()[0])
+	}
+}
@@ -0,0 +1,52 @@
+package lazyerrors
+
+// Result - holds either a value or an error, which can be unwrapped later inside of a Catch scope.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok - returns a successful Result holding value v.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// Err - returns a failed Result holding error err.
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// NewResult - returns a Result from a common (value, error) pair.
+func NewResult[T any](v T, err error) Result[T] {
+	return Result[T]{value: v, err: err}
+}
+
+// Map - applies fn to the value of a successful Result, failed Result is passed as is.
+func Map[T, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.err != nil {
+		return Result[U]{err: r.err}
+	}
+
+	return Result[U]{value: fn(r.value)}
+}
+
+// Get - checks the error of Result with Try and returns its value.
+func (r Result[T]) Get() T {
+	Try(r.err)
+
+	return r.value
+}
+
+// OrElse - returns the value of Result or def if Result holds an error.
+func (r Result[T]) OrElse(def T) T {
+	if r.err != nil {
+		return def
+	}
+
+	return r.value
+}
+
+// Unpack - returns the value and the error of Result.
+func (r Result[T]) Unpack() (T, error) {
+	return r.value, r.err
+}
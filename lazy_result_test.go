@@ -0,0 +1,48 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	results := make(chan Result[int], 2)
+	results <- NewResult(strconv.Atoi("42"))
+	results <- NewResult(strconv.Atoi("test"))
+	close(results)
+
+	var values []int
+
+	err := testWrapper(Try, Catch, func() error {
+		for r := range results {
+			values = append(values, r.Get())
+		}
+
+		return nil
+	})
+	if err == nil || len(values) != 1 || values[0] != 42 {
+		t.Fatal("unexpected:", values, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	if v := Err[int](errors.New("test error")).OrElse(1); v != 1 {
+		t.Fatal("unexpected:", v)
+	}
+
+	if v := Ok(1).OrElse(2); v != 1 {
+		t.Fatal("unexpected:", v)
+	}
+
+	if v, err := Map(Ok(21), func(i int) string { return strconv.Itoa(i * 2) }).Unpack(); err != nil || v != "42" {
+		t.Fatal("unexpected:", v, err)
+	}
+
+	customError := errors.New("test error")
+
+	if _, err := Map(Err[int](customError), strconv.Itoa).Unpack(); !errors.Is(err, customError) {
+		t.Fatal("unexpected:", err)
+	}
+}
@@ -0,0 +1,98 @@
+package lazyerrors
+
+import (
+	"context"
+	"errors"
+)
+
+type (
+	// retryConfig - settings of Retry.
+	retryConfig struct {
+		backoff Backoff
+		retry   func(err error) bool
+	}
+	// RetryOption - option of Retry and RetryCtx.
+	RetryOption func(c *retryConfig)
+)
+
+// WithBackoff - sets Backoff b of delays between attempts, nil is ignored. Attempts aren't delayed by default.
+func WithBackoff(b Backoff) RetryOption {
+	return func(c *retryConfig) {
+		if b != nil {
+			c.backoff = b
+		}
+	}
+}
+
+// RetryIf - sets policy pred deciding whether an error is retried, nil is ignored.
+//
+// By default errors are retried unless tagged with Permanent, see IsRetryable for retrying only transient ones.
+func RetryIf(pred func(err error) bool) RetryOption {
+	return func(c *retryConfig) {
+		if pred != nil {
+			c.retry = pred
+		}
+	}
+}
+
+// Retry - runs fn under Catch up to n times (at least once) until it succeeds, retrying on errors and recovered panics
+// allowed by RetryIf policy.
+//
+// Returns nil on success, else the last error with the number of attempts attached as "attempts" attribute with With,
+// so it can be checked with Try(Retry(n, fn)).
+func Retry(n int, fn func() error, opts ...RetryOption) error {
+	return RetryCtx(context.Background(), n, fn, opts...)
+}
+
+// RetryCtx - runs fn like Retry does, but stops retrying once ctx is done, delays between attempts included.
+//
+// If ctx is done before the first attempt, its cause tagged with Classify is returned.
+func RetryCtx(ctx context.Context, n int, fn func() error, opts ...RetryOption) error {
+	c := retryConfig{retry: isNotPermanent}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	var (
+		err      error
+		attempts int
+	)
+
+	for attempts < n || attempts == 0 {
+		if attempts > 0 && c.backoff != nil && !sleep(ctx, c.backoff.Delay(attempts)) {
+			break
+		}
+
+		if ctx.Err() != nil {
+			if attempts == 0 {
+				return Classify(context.Cause(ctx))
+			}
+
+			break
+		}
+
+		attempts++
+
+		if err = attempt(fn); err == nil {
+			return nil
+		}
+
+		if !c.retry(err) {
+			break
+		}
+	}
+
+	return With(err, "attempts", attempts)
+}
+
+// isNotPermanent - reports whether error err isn't tagged with Permanent, default policy of Retry.
+func isNotPermanent(err error) bool {
+	return !errors.Is(err, ErrPermanent)
+}
+
+// attempt - runs fn under Catch and returns either its result or the caught error.
+func attempt(fn func() error) (err error) {
+	defer Catch(&err)
+
+	return fn()
+}
@@ -0,0 +1,108 @@
+package lazyerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	flaky := func() error {
+		if calls++; calls < 3 {
+			return testFuncError()
+		}
+
+		return nil
+	}
+
+	if err := Retry(3, flaky); err != nil || calls != 3 {
+		t.Fatal("unexpected:", err, calls)
+	}
+
+	calls = 0
+
+	err := Do(func() { Try(Retry(2, func() error { calls++; return testFuncPanic() })) })
+	if !errors.Is(err, ErrPanic) || calls != 2 {
+		t.Fatal("unexpected:", err, calls)
+	}
+
+	if attrs := Attrs(err); len(attrs) != 1 || attrs[0].Key != "attempts" || attrs[0].Value.Int64() != 2 {
+		t.Fatal("unexpected:", attrs)
+	} else {
+		fmt.Println(Message(err), attrs)
+	}
+
+	calls = 0
+
+	if err := Retry(0, func() error { calls++; return testFuncError() }); err == nil || calls != 1 {
+		t.Fatal("unexpected:", err, calls)
+	}
+}
+
+func TestRetryCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+
+	err := RetryCtx(ctx, 5, func() error {
+		if calls++; calls == 2 {
+			cancel()
+		}
+
+		return testFuncError()
+	})
+	if err == nil || calls != 2 {
+		t.Fatal("unexpected:", err, calls)
+	}
+
+	if err := RetryCtx(ctx, 5, testFuncNoError); !errors.Is(err, ErrCanceled) || !errors.Is(err, context.Canceled) {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestRetryIf(t *testing.T) {
+	calls := 0
+
+	err := Retry(5, func() error { calls++; return Permanent(testFuncError()) })
+	if !errors.Is(err, ErrPermanent) || calls != 1 {
+		t.Fatal("unexpected:", err, calls)
+	}
+
+	calls = 0
+
+	err = Retry(5, func() error {
+		if calls++; calls < 3 {
+			return Retryable(testFuncError())
+		}
+
+		return testFuncError()
+	}, RetryIf(IsRetryable))
+	if err == nil || errors.Is(err, ErrRetryable) || calls != 3 {
+		t.Fatal("unexpected:", err, calls)
+	} else {
+		fmt.Println(err)
+	}
+
+	for _, testCase := range []struct {
+		err       error
+		retryable bool
+	}{
+		{nil, false},
+		{testFuncError(), false},
+		{Retryable(testFuncError()), true},
+		{context.DeadlineExceeded, true},
+		{Permanent(context.DeadlineExceeded), false},
+		{Permanent(Retryable(testFuncError())), false},
+	} {
+		if IsRetryable(testCase.err) != testCase.retryable {
+			t.Fatal("unexpected:", testCase.err)
+		}
+	}
+
+	if Retryable(nil) != nil || Permanent(nil) != nil || Class(Permanent(io.EOF)) != ErrPermanent {
+		t.Fatal("unexpected")
+	}
+}
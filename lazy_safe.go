@@ -0,0 +1,50 @@
+package lazyerrors
+
+import (
+	"sync"
+	"time"
+)
+
+// SafeCallback - returns fn wrapped into Catch for callers outside of your control (timers, third-party libraries).
+//
+// Caught errors are passed only to OnCatch hooks (and registered reporters), panics escaping from Catch
+// are caught with CatchAllWithStackFunc, so the callback never crashes the program.
+func SafeCallback(fn func()) func() {
+	return func() {
+		_ = runSafe(func() error {
+			fn()
+
+			return nil
+		})
+	}
+}
+
+// SafeAfterFunc - calls fn wrapped with SafeCallback in its own goroutine after duration d, see time.AfterFunc.
+func SafeAfterFunc(d time.Duration, fn func()) *time.Timer {
+	return time.AfterFunc(d, SafeCallback(fn))
+}
+
+// SafeTick - calls fn wrapped with SafeCallback every duration d in a new goroutine until stop is called.
+func SafeTick(d time.Duration, fn func()) (stop func()) {
+	var (
+		done = make(chan struct{})
+		once sync.Once
+		safe = SafeCallback(fn)
+	)
+
+	go func() {
+		ticker := time.NewTicker(d)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				safe()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { once.Do(func() { close(done) }) }
+}
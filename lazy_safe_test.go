@@ -0,0 +1,56 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSafeCallback(t *testing.T) {
+	defer ResetHooks()
+	defer SetDefaultCatch(nil)
+
+	caught := make(chan error, 4)
+
+	RegisterOnCatch(func(err error, _ bool) { caught <- err })
+
+	SafeCallback(func() { Try(testFuncError()) })()
+
+	// panics escaping from Catch are caught too.
+	SetDefaultCatch(CatchErrorHandler)
+	SafeCallback(func() { panic("test panic") })()
+	SetDefaultCatch(nil)
+
+	SafeAfterFunc(time.Millisecond, func() { panic("timer panic") })
+
+	if err := <-caught; err == nil || errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := <-caught; !errors.Is(err, ErrPanic) {
+			t.Fatal("unexpected:", err)
+		} else {
+			fmt.Println(Message(err))
+		}
+	}
+}
+
+func TestSafeTick(t *testing.T) {
+	ticks := make(chan struct{}, 2)
+
+	stop := SafeTick(time.Millisecond, func() {
+		select {
+		case ticks <- struct{}{}:
+		default:
+		}
+
+		panic("tick panic")
+	})
+
+	<-ticks
+	<-ticks
+	stop()
+	stop()
+}
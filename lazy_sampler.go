@@ -0,0 +1,145 @@
+package lazyerrors
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// samplerMaxFingerprints - maximum number of distinct fingerprints counted by Sampler between flushes,
+// occurrences of further fingerprints share the counter with empty fingerprint.
+const samplerMaxFingerprints = 1000
+
+type (
+	// Sampler - limits repeated identical errors: the first N occurrences of an error fingerprint
+	// are allowed, then only every M-th one until the counters are flushed.
+	//
+	// Sampler can be used from OnCatch hooks (see Hook) and by CatchAndLog (see SetLogSampler).
+	Sampler struct {
+		first      uint64
+		thereafter uint64
+		mu         sync.Mutex
+		counters   map[string]*sampleCounter
+	}
+	// SampleReport - number of suppressed occurrences of errors with the same fingerprint.
+	SampleReport struct {
+		Fingerprint string
+		Err         error
+		Suppressed  uint64
+	}
+	// sampleCounter - occurrences of errors with the same fingerprint.
+	sampleCounter struct {
+		err        error
+		seen       uint64
+		suppressed uint64
+	}
+)
+
+// logSampler - sampler of records emitted by CatchAndLog, set with SetLogSampler.
+var logSampler atomic.Pointer[Sampler]
+
+// SetLogSampler - sets optional sampler s of records emitted by CatchAndLog, nil disables it (default).
+func SetLogSampler(s *Sampler) {
+	logSampler.Store(s)
+}
+
+// NewSampler - returns Sampler allowing first occurrences and then every thereafter-th one (0 suppresses the rest).
+func NewSampler(first, thereafter int) *Sampler {
+	return &Sampler{
+		first:      uint64(max(first, 0)),
+		thereafter: uint64(max(thereafter, 0)),
+		counters:   make(map[string]*sampleCounter),
+	}
+}
+
+// Allow - counts an occurrence of error err and reports whether it should be processed.
+//
+// Once samplerMaxFingerprints distinct fingerprints are counted, errors with new ones are sampled together
+// under empty fingerprint until the counters are flushed.
+func (s *Sampler) Allow(err error) bool {
+	if s == nil || err == nil {
+		return true
+	}
+
+	key := Fingerprint(err)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counters[key]
+	if !ok && len(s.counters) >= samplerMaxFingerprints {
+		key = ""
+		c, ok = s.counters[key]
+	}
+
+	if !ok {
+		c = &sampleCounter{err: err}
+		s.counters[key] = c
+	}
+
+	c.seen++
+
+	if c.seen <= s.first || (s.thereafter > 0 && (c.seen-s.first)%s.thereafter == 0) {
+		return true
+	}
+
+	c.suppressed++
+
+	return false
+}
+
+// Hook - returns OnCatch hook that passes only allowed errors to fn.
+func (s *Sampler) Hook(fn func(err error, recovered bool)) func(err error, recovered bool) {
+	return func(err error, recovered bool) {
+		if s.Allow(err) {
+			fn(err, recovered)
+		}
+	}
+}
+
+// Flush - returns reports of suppressed errors (sorted by fingerprint) and resets all counters.
+func (s *Sampler) Flush() []SampleReport {
+	s.mu.Lock()
+	counters := s.counters
+	s.counters = make(map[string]*sampleCounter)
+	s.mu.Unlock()
+
+	var reports []SampleReport
+
+	for key, c := range counters {
+		if c.suppressed > 0 {
+			reports = append(reports, SampleReport{Fingerprint: key, Err: c.err, Suppressed: c.suppressed})
+		}
+	}
+
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Fingerprint < reports[j].Fingerprint })
+
+	return reports
+}
+
+// Run - flushes the counters every interval and passes non-empty reports to fn until stop is called.
+func (s *Sampler) Run(interval time.Duration, fn func([]SampleReport)) (stop func()) {
+	var (
+		done = make(chan struct{})
+		once sync.Once
+	)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if reports := s.Flush(); len(reports) > 0 {
+					fn(reports)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { once.Do(func() { close(done) }) }
+}
@@ -0,0 +1,101 @@
+package lazyerrors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSampler(t *testing.T) {
+	s := NewSampler(2, 3)
+
+	allowed := 0
+
+	for i := 0; i < 10; i++ {
+		if s.Allow(testWrapper(TryWrapErrorFunc, CatchAllFunc, testFuncError)) {
+			allowed++
+		}
+	}
+	// 2 first, then 5th and 8th.
+	if allowed != 4 {
+		t.Fatal("unexpected:", allowed)
+	}
+
+	if !s.Allow(testWrapper(TryWrapErrorFunc, CatchAllFunc, testFuncPanic)) {
+		t.Fatal("unexpected suppression")
+	}
+
+	reports := s.Flush()
+	if len(reports) != 1 || reports[0].Suppressed != 6 {
+		t.Fatal("unexpected:", reports)
+	} else {
+		fmt.Printf("%+v\n", reports)
+	}
+
+	if reports := s.Flush(); len(reports) != 0 {
+		t.Fatal("unexpected:", reports)
+	}
+}
+
+func TestSamplerRun(t *testing.T) {
+	s := NewSampler(0, 0)
+	result := make(chan []SampleReport, 1)
+
+	stop := s.Run(time.Millisecond, func(reports []SampleReport) { result <- reports })
+	defer stop()
+
+	hook := s.Hook(func(error, bool) { t.Fatal("unexpected hook call") })
+	hook(testFuncError(), false)
+
+	if reports := <-result; len(reports) != 1 || reports[0].Suppressed != 1 {
+		t.Fatal("unexpected:", reports)
+	}
+}
+
+func TestLogSampler(t *testing.T) {
+	defer SetLogSampler(nil)
+
+	SetLogSampler(NewSampler(1, 0))
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	for i := 0; i < 3; i++ {
+		var err error
+
+		func() {
+			defer CatchAndLog(logger, &err)
+			Try(testFuncError())
+		}()
+	}
+
+	if n := strings.Count(buf.String(), "\n"); n != 1 {
+		t.Fatal("unexpected:", buf.String())
+	}
+}
+
+func TestSamplerMaxFingerprints(t *testing.T) {
+	s := NewSampler(1, 0)
+
+	allowed := 0
+
+	for i := 0; i < samplerMaxFingerprints+10; i++ {
+		if s.Allow(errors.New("user " + strings.Repeat("x", i) + " not found")) {
+			allowed++
+		}
+	}
+
+	if len(s.counters) != samplerMaxFingerprints+1 || allowed != samplerMaxFingerprints+1 {
+		t.Fatal("unexpected:", len(s.counters), allowed)
+	}
+
+	reports := s.Flush()
+	if len(reports) != 1 || reports[0].Fingerprint != "" || reports[0].Suppressed != 9 {
+		t.Fatal("unexpected:", reports)
+	}
+}
@@ -0,0 +1,41 @@
+package lazyerrors
+
+import "context"
+
+// LazyScope - structured concurrency scope: goroutines are run under Catch, the first failure cancels
+// the scope context and is returned by Wait with the stack of the place where the failed goroutine was started.
+type LazyScope struct {
+	group *Group
+	ctx   context.Context
+}
+
+// Scope - returns LazyScope with a context derived from ctx.
+//
+//	s := lazyerrors.Scope(ctx)
+//	s.Go(func(ctx context.Context) { lazyerrors.Try(fetch(ctx, a)) })
+//	s.Go(func(ctx context.Context) { lazyerrors.Try(fetch(ctx, b)) })
+//	lazyerrors.Try(s.Wait())
+func Scope(ctx context.Context) *LazyScope {
+	group, ctx := GroupWithContext(ctx)
+
+	return &LazyScope{group: group, ctx: ctx}
+}
+
+// Context - returns the scope context, it's canceled by the first failure or Wait.
+func (s *LazyScope) Context() context.Context {
+	return s.ctx
+}
+
+// Go - runs fn with the scope context under Catch in a new goroutine, its failure is wrapped into LazyErrorWithOrigin.
+func (s *LazyScope) Go(fn func(ctx context.Context)) {
+	origin := NewOrigin()
+
+	s.group.Go(func() error {
+		return origin.Wrap(runSafe(func() error { fn(s.ctx); return nil }))
+	})
+}
+
+// Wait - blocks until all goroutines are finished and returns the first failure.
+func (s *LazyScope) Wait() error {
+	return s.group.Wait()
+}
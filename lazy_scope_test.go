@@ -0,0 +1,40 @@
+package lazyerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestScope(t *testing.T) {
+	s := Scope(context.Background())
+
+	s.Go(func(ctx context.Context) {
+		<-ctx.Done()
+	})
+	s.Go(func(context.Context) {
+		Try(testFuncPanic())
+	})
+
+	err := s.Wait()
+
+	var withOrigin *LazyErrorWithOrigin
+
+	if !errors.Is(err, ErrPanic) || !errors.As(err, &withOrigin) || withOrigin.Origin.Frames()[0].Function != packagePrefix+"TestScope" {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	if !errors.Is(context.Cause(s.Context()), ErrPanic) {
+		t.Fatal("unexpected:", context.Cause(s.Context()))
+	}
+
+	s = Scope(context.Background())
+	s.Go(func(context.Context) {})
+
+	if err := s.Wait(); err != nil || s.Context().Err() == nil {
+		t.Fatal("unexpected:", err, s.Context().Err())
+	}
+}
@@ -0,0 +1,48 @@
+package lazyerrors
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// singleLine - formats panics into a single line, set with SetSingleLine.
+var singleLine atomic.Bool
+
+// SetSingleLine - enables or disables formatting LazyErrorFromPanic into a single line with escaped newlines
+// and a flattened stack, it's disabled by default.
+func SetSingleLine(on bool) {
+	singleLine.Store(on)
+}
+
+// singleLineFrames - maximum number of frames kept in a single line report.
+const singleLineFrames = 5
+
+// escaper - escapes line breaks and tabs for single line reports.
+var escaper = strings.NewReplacer("\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// singleLine - returns the report flattened into a single line, stack is truncated to singleLineFrames.
+func (e *LazyErrorFromPanic) singleLine() string {
+	var stack string
+
+	if e.Stack != "" {
+		stack = strings.Join(strings.Fields(e.Stack), " ")
+	} else {
+		frames := e.Frames()
+
+		parts := make([]string, 0, singleLineFrames+1)
+		for i, frame := range frames {
+			if i == singleLineFrames {
+				parts = append(parts, fmt.Sprintf("... %d more", len(frames)-i))
+
+				break
+			}
+
+			parts = append(parts, fmt.Sprintf("%s %s:%d", frame.ShortFunction(), frame.file(), frame.Line))
+		}
+
+		stack = strings.Join(parts, " <- ")
+	}
+
+	return fmt.Sprintf("[%s]: %s [stack]: %s", e.headline(), escaper.Replace(e.recovered()), escaper.Replace(truncateStack(stack)))
+}
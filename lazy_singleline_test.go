@@ -0,0 +1,32 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSingleLine(t *testing.T) {
+	defer SetSingleLine(false)
+
+	SetSingleLine(true)
+
+	err := testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, func() error { panic("multi\nline") })
+
+	var panicErr *LazyErrorFromPanic
+
+	if !errors.As(err, &panicErr) || strings.Contains(err.Error(), "\n") || !strings.Contains(err.Error(), `multi\nline`) {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	if report := panicErr.Report(); !strings.Contains(report, "\n[stack]:\n") {
+		t.Fatal("unexpected:", report)
+	}
+
+	if err := NewErrorFromPanic("test panic", []byte("goroutine 1 [running]:\nmain.main()\n")); strings.Contains(err.Error(), "\n") {
+		t.Fatal("unexpected:", err)
+	}
+}
@@ -0,0 +1,125 @@
+package lazyerrors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy - behaviour of Sink when its buffer is full.
+type OverflowPolicy uint8
+
+const (
+	// OverflowBlock - blocks the sender until there's room in the buffer (default).
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest - drops the oldest buffered error to make room for the new one,
+	// it acts like OverflowDropNewest for unbuffered sinks.
+	OverflowDropOldest
+	// OverflowDropNewest - drops the new error, keeping the buffered ones.
+	OverflowDropNewest
+)
+
+// Sink - buffered channel of errors fed by catch handlers, e.g. for supervisors aggregating failures of goroutines.
+type Sink struct {
+	ch      chan error
+	policy  atomic.Uint32
+	dropped atomic.Uint64
+	mu      sync.RWMutex
+	closed  bool
+}
+
+// NewSink - returns Sink with a given buffer size and OverflowBlock policy.
+func NewSink(buffer int) *Sink {
+	return &Sink{ch: make(chan error, max(buffer, 0))}
+}
+
+// SetOverflow - sets overflow policy p of the sink.
+func (s *Sink) SetOverflow(p OverflowPolicy) *Sink {
+	s.policy.Store(uint32(p))
+
+	return s
+}
+
+// C - returns channel errors are received from, it's closed by Close.
+func (s *Sink) C() <-chan error {
+	return s.ch
+}
+
+// Dropped - returns number of errors dropped due to overflow.
+func (s *Sink) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Send - sends non-nil error err according to the overflow policy, errors sent after Close are dropped.
+func (s *Sink) Send(err error) {
+	if err == nil {
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		s.dropped.Add(1)
+
+		return
+	}
+
+	policy := OverflowPolicy(s.policy.Load())
+	// without a buffer there's nothing to drop to make room.
+	if policy == OverflowDropOldest && cap(s.ch) == 0 {
+		policy = OverflowDropNewest
+	}
+
+	switch policy {
+	case OverflowDropOldest:
+		for {
+			select {
+			case s.ch <- err:
+				return
+			default:
+			}
+			// make room by dropping the oldest error.
+			select {
+			case <-s.ch:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	case OverflowDropNewest:
+		select {
+		case s.ch <- err:
+		default:
+			s.dropped.Add(1)
+		}
+	default:
+		s.ch <- err
+	}
+}
+
+// Hook - OnCatch hook sending every caught error to the sink.
+func (s *Sink) Hook(err error, _ bool) {
+	s.Send(err)
+}
+
+// Catch - catches thrown error or panic like CatchAllWithStackFunc, assigns it and sends it to the sink.
+func (s *Sink) Catch(ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		*ep = recoveredError(r)
+		s.Send(*ep)
+	}
+}
+
+// Close - closes the receive channel, blocked senders must be unblocked by receivers first.
+func (s *Sink) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
@@ -0,0 +1,92 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestSink(t *testing.T) {
+	s := NewSink(2)
+
+	for _, f := range []func() error{testFuncNoError, testFuncError, testFuncPanic} {
+		func() {
+			var err error
+
+			defer s.Catch(&err)
+			Try(f())
+		}()
+	}
+
+	s.Close()
+	s.Send(testFuncError())
+
+	var caught []error
+	for err := range s.C() {
+		caught = append(caught, err)
+	}
+
+	if len(caught) != 2 || !errors.Is(caught[1], ErrPanic) || s.Dropped() != 1 {
+		t.Fatal("unexpected:", caught, s.Dropped())
+	} else {
+		fmt.Println(caught)
+	}
+}
+
+func TestSinkOverflow(t *testing.T) {
+	for _, tc := range []struct {
+		policy   OverflowPolicy
+		expected string
+	}{
+		{OverflowDropOldest, "3"},
+		{OverflowDropNewest, "1"},
+	} {
+		s := NewSink(1).SetOverflow(tc.policy)
+
+		for _, msg := range []string{"1", "2", "3"} {
+			s.Send(errors.New(msg))
+		}
+
+		if err := <-s.C(); err.Error() != tc.expected || s.Dropped() != 2 {
+			t.Fatal("unexpected:", tc.policy, err, s.Dropped())
+		}
+	}
+
+	defer ResetHooks()
+
+	s := NewSink(1)
+
+	RegisterOnCatch(s.Hook)
+
+	_ = testWrapper(Try, Catch, testFuncError)
+
+	if err := <-s.C(); err == nil {
+		t.Fatal("unexpected: nil error")
+	}
+}
+
+func TestSinkUnbufferedDropOldest(t *testing.T) {
+	s := NewSink(0).SetOverflow(OverflowDropOldest)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		s.Send(io.EOF)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("unexpected: Send is blocked")
+	}
+
+	if s.Dropped() != 1 {
+		t.Fatal("unexpected:", s.Dropped())
+	}
+
+	s.Close()
+}
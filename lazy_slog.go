@@ -0,0 +1,92 @@
+package lazyerrors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+// errorLogLevel - level of records of errors relative to slog.LevelError, set with SetErrorLogLevel.
+var errorLogLevel atomic.Int64
+
+// SetErrorLogLevel - sets level of records emitted by CatchAndLog for errors, panics are always logged
+// with slog.LevelError (the default level of errors too).
+func SetErrorLogLevel(level slog.Level) {
+	errorLogLevel.Store(int64(level - slog.LevelError))
+}
+
+// ErrorLogLevel - returns level of records emitted by CatchAndLog for errors.
+func ErrorLogLevel() slog.Level {
+	return slog.LevelError + slog.Level(errorLogLevel.Load())
+}
+
+// CatchAndLog - catches thrown error or panic like CatchAllWithStackFunc, assigns it and logs it with logger.
+//
+// Records can be sampled with SetLogSampler.
+func CatchAndLog(logger *slog.Logger, ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		*ep = recoveredError(r)
+
+		if logger != nil {
+			logError(logger, *ep)
+		}
+	}
+}
+
+// LogAttrs - returns structured slog attributes of error err: message, caller, stack and panic if present.
+//
+// Attributes attached with With are appended as is.
+func LogAttrs(err error) []slog.Attr {
+	var (
+		withCaller *LazyErrorWithCaller
+		withStack  *LazyErrorWithStack
+		fromPanic  *LazyErrorFromPanic
+		attrs      = []slog.Attr{slog.String("error", Message(err))}
+	)
+
+	switch {
+	case errors.As(err, &fromPanic):
+		attrs = append(attrs,
+			slog.String("panic", redact(fromPanic.recovered())),
+			slog.String("stack", redact(fromPanic.truncatedStack())),
+		)
+
+		if fromPanic.Kind != PanicValue {
+			attrs = append(attrs, slog.String("panic_kind", fromPanic.Kind.String()))
+		}
+	case errors.As(err, &withStack):
+		if frames := withStack.Frames(); len(frames) > 0 {
+			attrs = append(attrs, slog.String("caller", strings.TrimSuffix(frames[0].caller(), ": ")))
+		}
+
+		attrs = append(attrs, slog.String("stack", redact(truncateStack(withStack.Stack()))))
+	case errors.As(err, &withCaller):
+		if withCaller.Caller != "" {
+			attrs = append(attrs, slog.String("caller", strings.TrimSuffix(withCaller.Caller, ": ")))
+		}
+	}
+
+	return append(attrs, Attrs(err)...)
+}
+
+// logError - logs error err with logger, level depends on whether err is a recovered panic.
+//
+// Errors suppressed by the sampler set with SetLogSampler aren't logged.
+func logError(logger *slog.Logger, err error) {
+	if !logSampler.Load().Allow(err) {
+		return
+	}
+
+	level, msg := ErrorLogLevel(), "error caught"
+	if errors.Is(err, ErrPanic) {
+		level, msg = slog.LevelError, "panic recovered"
+	}
+
+	logger.LogAttrs(context.Background(), level, msg, LogAttrs(err)...)
+}
@@ -0,0 +1,56 @@
+package lazyerrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestCatchAndLog(t *testing.T) {
+	if ErrorLogLevel() != slog.LevelError {
+		t.Fatal("unexpected:", ErrorLogLevel())
+	}
+
+	defer SetErrorLogLevel(slog.LevelError)
+
+	SetErrorLogLevel(slog.LevelWarn)
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	for _, f := range []func() error{testFuncNoError, testFuncError, testFuncPanic} {
+		var err error
+
+		func() {
+			defer CatchAndLog(logger, &err)
+			Try(f())
+		}()
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatal("unexpected:", lines)
+	}
+
+	var records [2]map[string]interface{}
+
+	for i, line := range lines {
+		if err := json.Unmarshal([]byte(line), &records[i]); err != nil {
+			t.Fatal("unexpected:", err)
+		}
+
+		fmt.Println(line)
+	}
+
+	if records[0]["level"] != "WARN" || records[0]["error"] != "test error" || (callerEnabled && records[0]["caller"] == nil) {
+		t.Fatal("unexpected:", records[0])
+	}
+
+	if records[1]["level"] != "ERROR" || records[1]["panic"] != "test panic" || records[1]["stack"] == nil {
+		t.Fatal("unexpected:", records[1])
+	}
+}
@@ -0,0 +1,109 @@
+package lazyerrors
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// onReport - hooks registered with RegisterOnReport.
+	onReport registry[func(err error)]
+	// ambient - collectors bound to goroutines by Collector.Do, keyed by goroutine ID.
+	ambient sync.Map
+	// ambientCount - number of running Collector.Do calls, goroutine IDs aren't looked up if there are none.
+	ambientCount atomic.Int64
+)
+
+// collectorKey - context key of Collector set with Collector.Context.
+type collectorKey struct{}
+
+// Report - records non-nil error err wrapped like TryWrapErrorFunc does without throwing it (soft failure).
+//
+// The error is passed to OnReport hooks and collected by the Collector running the current goroutine
+// with Collector.Do, if any. Unlike Try, execution proceeds normally.
+func Report(err error) {
+	if err == nil {
+		return
+	}
+
+	err = report(err)
+
+	if c := boundCollector(); c != nil {
+		c.Collect(err)
+	}
+}
+
+// ReportC - records non-nil error err like Report, but it's collected by the Collector carried by ctx
+// (see Collector.Context), so goroutines spawned inside of Collector.Do can report to it as well.
+func ReportC(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+
+	err = report(err)
+
+	if c, ok := ctx.Value(collectorKey{}).(*Collector); ok {
+		c.Collect(err)
+	} else if c := boundCollector(); c != nil {
+		c.Collect(err)
+	}
+}
+
+// report - wraps error err and passes it to OnReport hooks.
+func report(err error) error {
+	err = wrapError(err)
+
+	for _, fn := range onReport.load() {
+		fn(err)
+	}
+
+	return err
+}
+
+// boundCollector - returns the Collector bound to the current goroutine by Collector.Do, nil if there's none.
+func boundCollector() *Collector {
+	if ambientCount.Load() == 0 {
+		return nil
+	}
+
+	if c, ok := ambient.Load(goroutineID()); ok {
+		return c.(*Collector)
+	}
+
+	return nil
+}
+
+// RegisterOnReport - registers hook fn called by Report with every reported error.
+func RegisterOnReport(fn func(err error)) {
+	onReport.add(fn)
+}
+
+// Context - returns a copy of ctx carrying c, so ReportC with it is collected by c in any goroutine.
+func (c *Collector) Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, collectorKey{}, c)
+}
+
+// Do - runs fn under Catch with c bound to the current goroutine, so Report inside of fn is collected by c.
+//
+// Goroutines spawned by fn aren't bound to c, use ReportC with Collector.Context there.
+// Returns errors.Join of the errors collected by c and the caught one, nil if there are none.
+func (c *Collector) Do(fn func()) error {
+	ambientCount.Add(1)
+	defer ambientCount.Add(-1)
+
+	id := goroutineID()
+
+	prev, bound := ambient.Swap(id, c)
+	defer func() {
+		if bound {
+			ambient.Store(id, prev)
+		} else {
+			ambient.Delete(id)
+		}
+	}()
+
+	err := Do(fn)
+
+	return appendError(c.Err(), err)
+}
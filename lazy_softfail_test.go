@@ -0,0 +1,99 @@
+package lazyerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	defer ResetHooks()
+
+	var reported []error
+
+	RegisterOnReport(func(err error) { reported = append(reported, err) })
+
+	Report(nil)
+	Report(io.EOF)
+
+	if len(reported) != 1 || !errors.Is(reported[0], io.EOF) || (callerEnabled && !strings.Contains(reported[0].Error(), "lazy_softfail_test.go")) {
+		t.Fatal("unexpected:", reported)
+	}
+
+	var (
+		c     Collector
+		after bool
+	)
+
+	err := c.Do(func() {
+		Report(io.ErrUnexpectedEOF)
+		Report(testFuncError())
+		Try(testFuncPanic())
+		after = true
+	})
+
+	errs, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(errs.Unwrap()) != 3 || !errors.Is(err, io.ErrUnexpectedEOF) || !errors.Is(err, ErrPanic) || after || c.Len() != 2 {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	// the collector isn't bound after Do.
+	Report(io.EOF)
+
+	if c.Len() != 2 || len(reported) != 4 {
+		t.Fatal("unexpected:", c.Len(), reported)
+	}
+
+	if err := new(Collector).Do(func() {}); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestCollectorDoLimit(t *testing.T) {
+	c := new(Collector).Limit(2)
+
+	err := c.Do(func() {
+		Report(io.EOF)
+		Report(io.ErrUnexpectedEOF)
+	})
+
+	// both reported errors and the limit error, each of them once.
+	errs, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(errs.Unwrap()) != 3 || !errors.Is(errs.Unwrap()[2], ErrLimitReached) || errors.Is(errs.Unwrap()[2], io.EOF) {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestReportC(t *testing.T) {
+	var c Collector
+
+	ctx := c.Context(context.Background())
+
+	err := c.Do(func() {
+		done := make(chan struct{})
+
+		// the spawned goroutine isn't bound to c, but ctx carries it.
+		go func() {
+			defer close(done)
+
+			Report(io.EOF)
+			ReportC(ctx, io.ErrUnexpectedEOF)
+		}()
+
+		<-done
+	})
+
+	if c.Len() != 1 || !errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		t.Fatal("unexpected:", err)
+	}
+
+	// without a collector in ctx the bound one is used.
+	if err := c.Do(func() { ReportC(context.Background(), io.EOF) }); c.Len() != 2 || !errors.Is(err, io.EOF) {
+		t.Fatal("unexpected:", err)
+	}
+}
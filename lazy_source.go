@@ -0,0 +1,48 @@
+package lazyerrors
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// sourceLines - number of source lines around frames, set with SetSourceLines.
+var sourceLines atomic.Int64
+
+// SetSourceLines - sets number n of source lines around each frame included into formatted stacks,
+// 0 (default) disables it.
+//
+// Source is included only when the files are available, e.g. in development environments and tests.
+func SetSourceLines(n int) {
+	sourceLines.Store(int64(n))
+}
+
+// sourceCache - contents of source files read while formatting a single stack.
+type sourceCache map[string][][]byte
+
+// snippet - writes lines of source around the frame line, prefixed with indent, if the file is available.
+func (c sourceCache) snippet(b *strings.Builder, frame Frame, around int) {
+	lines, ok := c[frame.File]
+	if !ok {
+		if data, err := os.ReadFile(frame.File); err == nil {
+			lines = bytes.Split(data, []byte("\n"))
+		}
+
+		c[frame.File] = lines
+	}
+
+	for n := frame.Line - around; n <= frame.Line+around; n++ {
+		if n < 1 || n > len(lines) {
+			continue
+		}
+
+		marker := " "
+		if n == frame.Line {
+			marker = ">"
+		}
+
+		fmt.Fprintf(b, "\t%s%5d | %s\n", marker, n, bytes.TrimRight(lines[n-1], "\r"))
+	}
+}
@@ -0,0 +1,24 @@
+package lazyerrors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSourceLines(t *testing.T) {
+	defer SetSourceLines(0)
+
+	SetSourceLines(2)
+
+	err := testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, testFuncPanic)
+	if !strings.Contains(err.Error(), `| 	panic("test panic")`) || !strings.Contains(err.Error(), "\t>") {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	if stack := formatFrames([]Frame{{Function: "main.main", File: "/nonexistent/main.go", Line: 1}}); strings.Contains(stack, "|") {
+		t.Fatal("unexpected:", stack)
+	}
+}
@@ -0,0 +1,363 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	// trimPath - trimmer of file paths set with SetTrimPath.
+	trimPath atomic.Pointer[func(frame Frame) string]
+	// frameFilter - filter of frames set with SetFrameFilter, FilterInternalFramesFunc if unset.
+	frameFilter atomic.Pointer[func(frame Frame) bool]
+	// callerFunction - adds function names to callers, set with SetCallerFunction.
+	callerFunction atomic.Bool
+)
+
+// SetTrimPath - sets optional trimmer fn of file paths in callers and formatted stacks, nil disables it (default).
+func SetTrimPath(fn func(frame Frame) string) {
+	if fn == nil {
+		trimPath.Store(nil)
+
+		return
+	}
+
+	trimPath.Store(&fn)
+}
+
+// SetFrameFilter - sets fn reporting whether a frame is kept in stacks, defaults to FilterInternalFramesFunc
+// (nil keeps all).
+func SetFrameFilter(fn func(frame Frame) bool) {
+	frameFilter.Store(&fn)
+}
+
+// SetCallerFunction - enables or disables adding function name in "pkg.Func" form to caller annotations,
+// it's disabled by default.
+func SetCallerFunction(on bool) {
+	callerFunction.Store(on)
+}
+
+// keepFrame - reports whether frame is kept by the filter set with SetFrameFilter.
+func keepFrame(frame Frame) bool {
+	fn := frameFilter.Load()
+	if fn == nil {
+		return FilterInternalFramesFunc(frame)
+	}
+
+	return *fn == nil || (*fn)(frame)
+}
+
+// maxStackDepth - maximum number of frames kept in captured stacks, set with SetMaxStackDepth.
+var maxStackDepth atomic.Int64
+
+// SetMaxStackDepth - sets maximum number of frames n kept in captured stacks, 0 means unlimited (default).
+func SetMaxStackDepth(n int) {
+	maxStackDepth.Store(int64(n))
+}
+
+// internalStackDepth - headroom for frames of this package and runtime captured with SetMaxStackDepth.
+const internalStackDepth = 8
+
+type (
+	// LazyErrorWithStack - custom error structure that contains the full call stack as program counters.
+	LazyErrorWithStack struct {
+		Err     error
+		Callers []uintptr
+		hops    []Frame
+	}
+	// StackTrace - stack of program counters compatible with StackTrace of github.com/pkg/errors.
+	StackTrace []StackFrame
+	// StackFrame - program counter of a single stack frame compatible with Frame of github.com/pkg/errors.
+	StackFrame uintptr
+	// Frame - structured location of a single stack frame.
+	Frame struct {
+		Function string
+		File     string
+		Line     int
+		PC       uintptr
+	}
+)
+
+// Error - error interface implementation.
+func (e *LazyErrorWithStack) Error() string {
+	if frames := e.Frames(); len(frames) > 0 {
+		return redact(frames[0].caller() + truncateMessage(e.Err.Error()))
+	}
+
+	return redact(truncateMessage(e.Err.Error()))
+}
+
+// Unwrap - error interface implementation.
+func (e *LazyErrorWithStack) Unwrap() error {
+	return e.Err
+}
+
+// Is - error interface implementation.
+func (e *LazyErrorWithStack) Is(err error) bool {
+	return errors.Is(e.Err, err)
+}
+
+// Stack - returns the call stack formatted in the same way as debug.Stack does.
+func (e *LazyErrorWithStack) Stack() string {
+	return formatFrames(e.Frames())
+}
+
+// Frames - returns structured frames of the call stack, skipping the frames of this package at the top.
+func (e *LazyErrorWithStack) Frames() []Frame {
+	return resolveFrames(e.Callers)
+}
+
+// StackTrace - returns raw (unfiltered) program counters of the call stack.
+func (e *LazyErrorWithStack) StackTrace() StackTrace {
+	return newStackTrace(e.Callers)
+}
+
+// NewErrorWithStack - captures the call stack and wraps it with error err into LazyErrorWithStack.
+func NewErrorWithStack(err error) error {
+	return &LazyErrorWithStack{
+		Err:     err,
+		Callers: callers(),
+	}
+}
+
+// TryWrapStackFunc - wraps non-nil error err into LazyErrorWithStack and throws it as a panic.
+func TryWrapStackFunc(err error) {
+	if err != nil {
+		switch err.(type) {
+		// if an error is already wrapped, then return it as is (recording a hop if enabled).
+		case *LazyErrorFromPanic, *LazyErrorWithCaller, *LazyErrorWithStack:
+			if recordHops.Load() {
+				err = withHop(err)
+			}
+
+			throw(err)
+		// else - wrap it into LazyErrorWithStack.
+		default:
+			throw(NewErrorWithStack(err))
+		}
+	}
+}
+
+// Frames - resolves the stack trace into unfiltered structured frames.
+func (st StackTrace) Frames() []Frame {
+	if len(st) == 0 {
+		return nil
+	}
+
+	pcs := make([]uintptr, len(st))
+	for i, pc := range st {
+		pcs[i] = uintptr(pc)
+	}
+
+	var (
+		resolved []Frame
+		frames   = runtime.CallersFrames(pcs)
+	)
+
+	for {
+		frame, more := frames.Next()
+		resolved = append(resolved, newFrame(frame))
+
+		if !more {
+			return resolved
+		}
+	}
+}
+
+// newStackTrace - converts program counters pcs into StackTrace.
+func newStackTrace(pcs []uintptr) StackTrace {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	st := make(StackTrace, len(pcs))
+	for i, pc := range pcs {
+		st[i] = StackFrame(pc)
+	}
+
+	return st
+}
+
+// newFrame - converts runtime frame into Frame.
+func newFrame(frame runtime.Frame) Frame {
+	return Frame{
+		Function: frame.Function,
+		File:     frame.File,
+		Line:     frame.Line,
+		PC:       frame.PC,
+	}
+}
+
+// FilterInternalFramesFunc - frame filter that drops runtime frames and frames of this package.
+func FilterInternalFramesFunc(frame Frame) bool {
+	return !strings.HasPrefix(frame.Function, "runtime.") && !isPackageFrame(frame)
+}
+
+// TrimPathPrefixFunc - returns a SetTrimPath handler that removes the first matching prefix from file paths.
+func TrimPathPrefixFunc(prefixes ...string) func(Frame) string {
+	return func(frame Frame) string {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(frame.File, prefix) {
+				return strings.TrimPrefix(frame.File, prefix)
+			}
+		}
+
+		return frame.File
+	}
+}
+
+// TrimPathPackageFunc - SetTrimPath handler that replaces file paths with package paths (-trimpath style).
+func TrimPathPackageFunc(frame Frame) string {
+	if frame.Function == "" {
+		return frame.File
+	}
+
+	return frame.Package() + "/" + filepath.Base(frame.File)
+}
+
+// Package - returns import path of the package the frame's function belongs to.
+func (f Frame) Package() string {
+	fn := f.Function
+	slash := strings.LastIndex(fn, "/") + 1
+
+	if dot := strings.Index(fn[slash:], "."); dot >= 0 {
+		return fn[:slash+dot]
+	}
+
+	return fn
+}
+
+// file - returns file path of the frame trimmed with the trimmer set with SetTrimPath.
+func (f Frame) file() string {
+	if fn := trimPath.Load(); fn != nil {
+		return (*fn)(f)
+	}
+
+	return f.File
+}
+
+// ShortFunction - returns function name of the frame in "pkg.Func" form.
+func (f Frame) ShortFunction() string {
+	return f.Function[strings.LastIndex(f.Function, "/")+1:]
+}
+
+// caller - returns caller annotation of the frame in "file:line: " or "pkg.Func file:line: " form.
+func (f Frame) caller() string {
+	if f == (Frame{}) {
+		return ""
+	}
+
+	if callerFunction.Load() && f.Function != "" {
+		return fmt.Sprintf("%s %s:%d: ", f.ShortFunction(), f.file(), f.Line)
+	}
+
+	return fmt.Sprintf("%s:%d: ", f.file(), f.Line)
+}
+
+// TryStack - wraps non-nil error err into LazyErrorWithStack regardless of Try and throws it as a panic.
+//
+// Unlike TryWrapStackFunc, errors wrapped into LazyErrorWithCaller are wrapped again to get the full stack.
+func TryStack(err error) {
+	if err != nil {
+		switch err.(type) {
+		// if an error already has a stack, then return it as is.
+		case *LazyErrorFromPanic, *LazyErrorWithStack:
+			throw(err)
+		// else - wrap it into LazyErrorWithStack.
+		default:
+			throw(NewErrorWithStack(err))
+		}
+	}
+}
+
+// topFrame - returns the first frame of the outermost error with frames in the chain of err, zero Frame if none.
+func topFrame(err error) Frame {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if e, ok := err.(interface{ Frames() []Frame }); ok {
+			if frames := e.Frames(); len(frames) > 0 {
+				return frames[0]
+			}
+		}
+	}
+
+	return Frame{}
+}
+
+// callers - returns program counters of the current goroutine stack, only the top frames are captured if Storming.
+func callers() []uintptr {
+	if downgrade() {
+		pcs := make([]uintptr, internalStackDepth)
+
+		return pcs[:runtime.Callers(2, pcs)]
+	}
+
+	if depth := int(maxStackDepth.Load()); depth > 0 {
+		pcs := make([]uintptr, depth+internalStackDepth)
+
+		return pcs[:runtime.Callers(2, pcs)]
+	}
+
+	pcs := make([]uintptr, 32)
+
+	for {
+		n := runtime.Callers(2, pcs)
+		if n < len(pcs) {
+			return pcs[:n]
+		}
+
+		pcs = make([]uintptr, len(pcs)*2)
+	}
+}
+
+// resolveFrames - resolves program counters pcs into frames filtered with SetFrameFilter and limited by SetMaxStackDepth.
+//
+// Frames of this package at the top are skipped regardless of the filter.
+func resolveFrames(pcs []uintptr) []Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	var (
+		resolved []Frame
+		frames   = runtime.CallersFrames(pcs)
+		depth    = int(maxStackDepth.Load())
+	)
+
+	for {
+		frame, more := frames.Next()
+		if f := newFrame(frame); (len(resolved) > 0 || !isPackageFrame(f)) && keepFrame(f) {
+			resolved = append(resolved, f)
+		}
+
+		if !more || (depth > 0 && len(resolved) >= depth) {
+			return resolved
+		}
+	}
+}
+
+// formatFrames - formats frames in the same way as debug.Stack does, source is added if SetSourceLines enabled it.
+func formatFrames(frames []Frame) string {
+	var (
+		b       strings.Builder
+		sources sourceCache
+		around  = int(sourceLines.Load())
+	)
+
+	if around > 0 {
+		sources = make(sourceCache)
+	}
+
+	for _, frame := range frames {
+		fmt.Fprintf(&b, "%s(...)\n\t%s:%d\n", frame.Function, frame.file(), frame.Line)
+
+		if sources != nil {
+			sources.snippet(&b, frame, around)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,216 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestTryWrapStackFunc(t *testing.T) {
+	err := testWrapper(TryWrapStackFunc, CatchAllFunc, func() error {
+		return testWrapper(TryWrapStackFunc, CatchAllFunc, testFuncError)
+	})
+
+	var stackErr *LazyErrorWithStack
+
+	if !errors.As(err, &stackErr) || !strings.Contains(err.Error(), "lazy_errors_test.go:") {
+		t.Fatal("unexpected:", err)
+	}
+
+	if stack := stackErr.Stack(); strings.Count(stack, "testWrapper") != 2 || strings.Contains(stack, "lazy_stack.go") {
+		t.Fatal("unexpected:", stack)
+	} else {
+		fmt.Printf("%v\n%s", err, stack)
+	}
+}
+
+func TestFrames(t *testing.T) {
+	type framer interface {
+		Frames() []Frame
+	}
+
+	for _, try := range testFrameTries() {
+		err := testWrapper(try, CatchAllWithStackFunc, testFuncError)
+
+		var f framer
+
+		if !errors.As(err, &f) || len(f.Frames()) == 0 || !strings.HasSuffix(f.Frames()[0].File, "lazy_errors_test.go") {
+			t.Fatal("unexpected:", err)
+		} else {
+			fmt.Printf("%+v\n", f.Frames()[0])
+		}
+	}
+
+	err := testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, testFuncPanic)
+
+	var panicErr *LazyErrorFromPanic
+
+	if !errors.As(err, &panicErr) || len(panicErr.Frames()) == 0 {
+		t.Fatal("unexpected:", err)
+	}
+
+	if frames := (&LazyErrorWithCaller{Err: err}).Frames(); frames != nil {
+		t.Fatal("unexpected:", frames)
+	}
+}
+
+func TestLazyPanicStack(t *testing.T) {
+	err := testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, testFuncPanic)
+
+	var panicErr *LazyErrorFromPanic
+
+	if !errors.As(err, &panicErr) || panicErr.Stack != "" || !strings.Contains(err.Error(), "testFuncPanic") {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := NewErrorFromPanic("test panic", []byte("test stack")); !strings.HasSuffix(err.Error(), "test stack") {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestTrimPath(t *testing.T) {
+	defer SetTrimPath(nil)
+
+	SetTrimPath(TrimPathPackageFunc)
+
+	for _, try := range testFrameTries() {
+		if err := testWrapper(try, CatchAllFunc, testFuncError); !strings.HasPrefix(err.Error(), "github.com/p-alexander/lazyerrors/lazy_errors_test.go:") {
+			t.Fatal("unexpected:", err)
+		} else {
+			fmt.Println(err)
+		}
+	}
+
+	err := testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, testFuncPanic)
+	if !strings.Contains(err.Error(), "\ttesting/testing.go:") {
+		t.Fatal("unexpected:", err)
+	}
+
+	_, file, _, _ := runtime.Caller(0)
+	SetTrimPath(TrimPathPrefixFunc("/nonexistent/", filepath.Dir(file)+"/"))
+
+	if err := testWrapper(TryWrapStackFunc, CatchAllFunc, testFuncError); !strings.HasPrefix(err.Error(), "lazy_errors_test.go:") {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestFrameFilter(t *testing.T) {
+	defer SetFrameFilter(FilterInternalFramesFunc)
+
+	err := testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, testFuncPanic)
+	if !strings.HasPrefix(err.(*LazyErrorFromPanic).Frames()[0].Function, packagePrefix+"testFuncPanic") || strings.Contains(err.Error(), "runtime.") {
+		t.Fatal("unexpected:", err)
+	}
+
+	SetFrameFilter(nil)
+
+	err = testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, testFuncPanic)
+	if !strings.Contains(err.Error(), "runtime.gopanic") {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestStackTrace(t *testing.T) {
+	type stackTracer interface {
+		StackTrace() StackTrace
+	}
+
+	for _, err := range []error{
+		testWrapper(TryWrapStackFunc, CatchAllFunc, testFuncError),
+		testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, testFuncPanic),
+	} {
+		var st stackTracer
+
+		if !errors.As(err, &st) || len(st.StackTrace()) == 0 {
+			t.Fatal("unexpected:", err)
+		}
+
+		found := false
+
+		for _, frame := range st.StackTrace().Frames() {
+			found = found || strings.HasSuffix(frame.Function, ".testWrapper")
+		}
+
+		if !found {
+			t.Fatal("unexpected:", st.StackTrace().Frames())
+		}
+	}
+}
+
+func TestMaxStackDepth(t *testing.T) {
+	defer SetMaxStackDepth(0)
+
+	SetMaxStackDepth(2)
+
+	var recurse func(int) error
+
+	recurse = func(i int) error {
+		if i == 0 {
+			return testWrapper(TryWrapStackFunc, CatchAllFunc, testFuncError)
+		}
+
+		return recurse(i - 1)
+	}
+
+	err := recurse(64)
+
+	var stackErr *LazyErrorWithStack
+
+	if !errors.As(err, &stackErr) || len(stackErr.Frames()) != 2 || len(stackErr.Callers) > 2+internalStackDepth {
+		t.Fatal("unexpected:", err, stackErr.Frames())
+	}
+
+	err = testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, testFuncPanic)
+	if frames := err.(*LazyErrorFromPanic).Frames(); len(frames) != 2 {
+		t.Fatal("unexpected:", frames)
+	}
+}
+
+func TestCallerFunction(t *testing.T) {
+	skipWithoutCaller(t)
+
+	defer SetCallerFunction(false)
+
+	SetCallerFunction(true)
+
+	for _, try := range []func(error){TryWrapErrorFunc, TryWrapStackFunc} {
+		if err := testWrapper(try, CatchAllFunc, testFuncError); !strings.HasPrefix(err.Error(), "lazyerrors.testWrapper ") {
+			t.Fatal("unexpected:", err)
+		} else {
+			fmt.Println(err)
+		}
+	}
+}
+
+func TestTryStack(t *testing.T) {
+	customError := errors.New("test error")
+
+	err := testWrapper(TryErrorFunc, CatchAllFunc, func() (err error) {
+		defer CatchAllFunc(&err)
+		TryStack(NewErrorWithCaller(customError))
+
+		return
+	})
+
+	var stackErr *LazyErrorWithStack
+
+	if !errors.As(err, &stackErr) || !errors.Is(err, customError) || !strings.Contains(stackErr.Stack(), "TestTryStack") {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+// testFrameTries - returns try handlers producing errors with frames, TryWrapErrorFunc is omitted without callers.
+func testFrameTries() []func(error) {
+	if !callerEnabled {
+		return []func(error){TryWrapStackFunc}
+	}
+
+	return []func(error){TryWrapErrorFunc, TryWrapStackFunc}
+}
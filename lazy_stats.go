@@ -0,0 +1,82 @@
+package lazyerrors
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// recordStats - counts errors per call site, set with SetRecordStats.
+var recordStats atomic.Bool
+
+// SetRecordStats - enables or disables counting errors thrown by built-in try handlers per call site (see Stats),
+// it's disabled by default.
+func SetRecordStats(on bool) {
+	recordStats.Store(on)
+}
+
+type (
+	// CallsiteStat - number of errors thrown by Try at a single call site.
+	CallsiteStat struct {
+		Frame    Frame
+		Count    uint64
+		LastErr  error
+		LastTime time.Time
+	}
+	// callsiteStats - counters of call sites keyed by program counter.
+	callsiteStats struct {
+		mu    sync.Mutex
+		sites map[uintptr]*CallsiteStat
+	}
+)
+
+// stats - call site counters updated by built-in try handlers if SetRecordStats enabled it.
+var stats = callsiteStats{sites: make(map[uintptr]*CallsiteStat)}
+
+// Stats - returns snapshot of call site counters sorted by count, the most failing call sites go first.
+func Stats() []CallsiteStat {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	snapshot := make([]CallsiteStat, 0, len(stats.sites))
+	for _, site := range stats.sites {
+		snapshot = append(snapshot, *site)
+	}
+
+	sort.Slice(snapshot, func(i, j int) bool {
+		if snapshot[i].Count != snapshot[j].Count {
+			return snapshot[i].Count > snapshot[j].Count
+		}
+
+		return snapshot[i].Frame.PC < snapshot[j].Frame.PC
+	})
+
+	return snapshot
+}
+
+// ResetStats - removes all call site counters.
+func ResetStats() {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.sites = make(map[uintptr]*CallsiteStat)
+}
+
+// recordCallsite - counts error err thrown at the current call site.
+func recordCallsite(err error) {
+	frame := caller()
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	site, ok := stats.sites[frame.PC]
+	if !ok {
+		site = &CallsiteStat{Frame: frame}
+		stats.sites[frame.PC] = site
+	}
+
+	site.Count++
+	site.LastErr = err
+	site.LastTime = time.Now()
+}
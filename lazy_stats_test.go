@@ -0,0 +1,40 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	defer SetRecordStats(false)
+	defer ResetStats()
+
+	SetRecordStats(true)
+
+	for i := 0; i < 3; i++ {
+		_ = testWrapper(Try, Catch, testFuncError)
+		_ = testWrapper(Try, Catch, testFuncNoError)
+
+		if i == 0 {
+			_ = Do(func() { Try1(0, errors.New("once")) })
+		}
+	}
+
+	s := Stats()
+	if len(s) != 2 || s[0].Count != 3 || s[1].Count != 1 || s[0].LastErr == nil || s[0].LastTime.IsZero() {
+		t.Fatal("unexpected:", s)
+	}
+
+	if s[0].Frame.Function != packagePrefix+"testWrapper" || s[1].Frame.Function != packagePrefix+"TestStats.func1" {
+		t.Fatal("unexpected:", s)
+	} else {
+		fmt.Println(s)
+	}
+
+	ResetStats()
+
+	if len(Stats()) != 0 {
+		t.Fatal("unexpected:", Stats())
+	}
+}
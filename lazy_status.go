@@ -0,0 +1,78 @@
+package lazyerrors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+type (
+	// StatusCoder - error carrying its own HTTP status code, it takes precedence over MapStatus.
+	StatusCoder interface {
+		StatusCode() int
+	}
+	// statusMapping - HTTP status code of errors matching target.
+	statusMapping struct {
+		target error
+		code   int
+	}
+)
+
+// statusMappings - mappings registered with MapStatus.
+var statusMappings registry[statusMapping]
+
+// defaultStatusMappings - mappings used by StatusOf if none of the registered ones matches.
+var defaultStatusMappings = []statusMapping{
+	{ErrInvalid, http.StatusBadRequest},
+	{ErrCircuitOpen, http.StatusServiceUnavailable},
+	{ErrTimeout, http.StatusGatewayTimeout},
+	{context.DeadlineExceeded, http.StatusGatewayTimeout},
+	{ErrPanic, http.StatusInternalServerError},
+}
+
+// MapStatus - registers HTTP status code of errors matching target via errors.Is, used by StatusOf.
+//
+// Mappings registered later take precedence, so defaults can be overridden.
+func MapStatus(target error, code int) {
+	statusMappings.add(statusMapping{target: target, code: code})
+}
+
+// StatusOf - returns HTTP status code of error err: 200 for nil, the code of StatusCoder in its chain,
+// the code registered with MapStatus for it or a default one (400 for ErrInvalid, 503 for ErrCircuitOpen,
+// 504 for timeouts), 500 otherwise.
+func StatusOf(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	var coder StatusCoder
+	if errors.As(err, &coder) {
+		if code := coder.StatusCode(); code > 0 {
+			return code
+		}
+	}
+
+	mappings := statusMappings.load()
+	for i := len(mappings) - 1; i >= 0; i-- {
+		if errors.Is(err, mappings[i].target) {
+			return mappings[i].code
+		}
+	}
+
+	for _, mapping := range defaultStatusMappings {
+		if errors.Is(err, mapping.target) {
+			return mapping.code
+		}
+	}
+
+	if IsTimeout(err) {
+		return http.StatusGatewayTimeout
+	}
+
+	return http.StatusInternalServerError
+}
+
+// ResetStatus - removes all mappings registered with MapStatus.
+func ResetStatus() {
+	statusMappings.reset()
+}
@@ -0,0 +1,47 @@
+package lazyerrors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+)
+
+type testStatusError struct{}
+
+func (testStatusError) Error() string { return "not found" }
+
+func (testStatusError) StatusCode() int { return http.StatusNotFound }
+
+func TestStatusOf(t *testing.T) {
+	defer ResetStatus()
+
+	for _, testCase := range []struct {
+		err  error
+		code int
+	}{
+		{nil, http.StatusOK},
+		{io.EOF, http.StatusInternalServerError},
+		{Invalid(io.EOF), http.StatusBadRequest},
+		{fmt.Errorf("query: %w", context.DeadlineExceeded), http.StatusGatewayTimeout},
+		{fmt.Errorf("db: %w", ErrCircuitOpen), http.StatusServiceUnavailable},
+		{Do(func() { Try(testFuncPanic()) }), http.StatusInternalServerError},
+		{Do(func() { Try(testStatusError{}) }), http.StatusNotFound},
+	} {
+		if code := StatusOf(testCase.err); code != testCase.code {
+			t.Fatal("unexpected:", testCase.err, code)
+		}
+	}
+
+	MapStatus(io.EOF, http.StatusBadRequest)
+	MapStatus(ErrInvalid, http.StatusUnprocessableEntity)
+
+	if code := StatusOf(Invalid(io.EOF)); code != http.StatusUnprocessableEntity {
+		t.Fatal("unexpected:", code)
+	}
+
+	if code := StatusOf(fmt.Errorf("read: %w", io.EOF)); code != http.StatusBadRequest {
+		t.Fatal("unexpected:", code)
+	}
+}
@@ -0,0 +1,80 @@
+package lazyerrors
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// stormRate - error throughput limit of Storming, set with SetStormRate.
+var stormRate atomic.Int64
+
+// storm - counters of errors in the current and previous second, and of downgraded behaviors.
+var storm struct {
+	second     atomic.Int64
+	count      atomic.Int64
+	previous   atomic.Int64
+	downgraded atomic.Uint64
+}
+
+// SetStormRate - sets number of thrown errors and recovered panics per second above which expensive behaviors are
+// downgraded (stacks are captured shallow, reporters are skipped), 0 disables it (default).
+func SetStormRate(rate int) {
+	stormRate.Store(int64(rate))
+}
+
+// Storming - reports whether error throughput exceeds the rate set with SetStormRate, so expensive behaviors
+// are downgraded.
+func Storming() bool {
+	rate := stormRate.Load()
+	if rate <= 0 {
+		return false
+	}
+
+	rollStorm(time.Now().Unix())
+
+	return storm.count.Load() > rate || storm.previous.Load() > rate
+}
+
+// Downgraded - returns number of behaviors downgraded since the start or the last ResetStorm.
+func Downgraded() uint64 {
+	return storm.downgraded.Load()
+}
+
+// ResetStorm - resets error throughput and downgraded behaviors counters.
+func ResetStorm() {
+	storm.second.Store(0)
+	storm.count.Store(0)
+	storm.previous.Store(0)
+	storm.downgraded.Store(0)
+}
+
+// recordStorm - counts an error for Storming, if the storm rate is set.
+func recordStorm() {
+	if stormRate.Load() > 0 {
+		rollStorm(time.Now().Unix())
+		storm.count.Add(1)
+	}
+}
+
+// downgrade - reports whether an expensive behavior should be downgraded and counts it if so.
+func downgrade() bool {
+	if !Storming() {
+		return false
+	}
+
+	storm.downgraded.Add(1)
+
+	return true
+}
+
+// rollStorm - starts counting errors of second now, the count of the past second is kept if it's adjacent.
+func rollStorm(now int64) {
+	if last := storm.second.Load(); last != now && storm.second.CompareAndSwap(last, now) {
+		count := storm.count.Swap(0)
+		if last != now-1 {
+			count = 0
+		}
+
+		storm.previous.Store(count)
+	}
+}
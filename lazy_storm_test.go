@@ -0,0 +1,61 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestStorm(t *testing.T) {
+	defer ResetHooks()
+	defer ResetStorm()
+	defer SetStormRate(0)
+
+	r := &testReporter{}
+
+	RegisterReporter(r)
+
+	full := Do(func() { testDeep(32, func() { TryStack(testFuncError()) }) })
+
+	SetStormRate(2)
+
+	for i := 0; i < 3; i++ {
+		_ = testWrapper(Try, Catch, testFuncError)
+	}
+
+	if !Storming() || len(r.errors) != 3 || Downgraded() != 1 {
+		t.Fatal("unexpected:", len(r.errors))
+	}
+
+	_ = testWrapper(Try, Catch, testFuncError)
+
+	shallow := Do(func() { testDeep(32, func() { TryStack(testFuncError()) }) })
+
+	var fullStack, shallowStack *LazyErrorWithStack
+	if !errors.As(full, &fullStack) || !errors.As(shallow, &shallowStack) ||
+		len(shallowStack.Callers) > internalStackDepth || len(shallowStack.Callers) >= len(fullStack.Callers) {
+		t.Fatal("unexpected:", full, shallow)
+	}
+
+	if len(r.errors) != 3 || Downgraded() != 4 {
+		t.Fatal("unexpected:", len(r.errors), Downgraded())
+	} else {
+		fmt.Println(shallow, Downgraded())
+	}
+
+	ResetStorm()
+
+	if Storming() || Downgraded() != 0 {
+		t.Fatal("unexpected:", Downgraded())
+	}
+}
+
+func testDeep(depth int, fn func()) {
+	if depth > 0 {
+		testDeep(depth-1, fn)
+
+		return
+	}
+
+	fn()
+}
@@ -0,0 +1,61 @@
+package lazyerrors
+
+import "errors"
+
+type (
+	// LazySwitch - catch handler that dispatches recovered errors to typed handlers via errors.As.
+	//
+	//	defer lazyerrors.CatchSwitch(&err).Case(&pathErr, onPath).Default(onOther).Catch()
+	LazySwitch struct {
+		ep    *error
+		cases []switchCase
+		def   func(error)
+	}
+	// switchCase - single typed case of LazySwitch.
+	switchCase struct {
+		target interface{}
+		fn     func(error)
+	}
+)
+
+// CatchSwitch - returns LazySwitch that assigns caught errors through ep (which can be nil).
+func CatchSwitch(ep *error) *LazySwitch {
+	return &LazySwitch{ep: ep}
+}
+
+// Case - adds a handler fn called when the error matches target via errors.As (target must be a non-nil pointer).
+func (s *LazySwitch) Case(target interface{}, fn func(error)) *LazySwitch {
+	s.cases = append(s.cases, switchCase{target: target, fn: fn})
+
+	return s
+}
+
+// Default - sets a handler fn called when the error matches none of the cases.
+func (s *LazySwitch) Default(fn func(error)) *LazySwitch {
+	s.def = fn
+
+	return s
+}
+
+// Catch - catches thrown error or panic like CatchAllWithStackFunc and dispatches it, must be deferred.
+func (s *LazySwitch) Catch() {
+	// recover from panic.
+	if r := recover(); r != nil {
+		err := recoveredError(r)
+		if s.ep != nil {
+			*s.ep = err
+		}
+		// dispatch to the first matching case.
+		for _, c := range s.cases {
+			if errors.As(err, c.target) {
+				c.fn(err)
+
+				return
+			}
+		}
+
+		if s.def != nil {
+			s.def(err)
+		}
+	}
+}
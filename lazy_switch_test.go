@@ -0,0 +1,42 @@
+package lazyerrors
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+func TestCatchSwitch(t *testing.T) {
+	var (
+		err      error
+		pathErr  *fs.PathError
+		panicErr *LazyErrorFromPanic
+		handled  []string
+	)
+
+	handle := func(name string) func(error) {
+		return func(error) { handled = append(handled, name) }
+	}
+
+	for _, f := range []func() error{
+		func() error { _, err := os.Open("/nonexistent/lazyerrors"); return err },
+		testFuncPanic,
+		testFuncError,
+	} {
+		func() {
+			defer CatchSwitch(&err).
+				Case(&pathErr, handle("path")).
+				Case(&panicErr, handle("panic")).
+				Default(handle("default")).
+				Catch()
+			Try(f())
+		}()
+	}
+
+	if fmt.Sprint(handled) != "[path panic default]" || err == nil || pathErr == nil || panicErr == nil {
+		t.Fatal("unexpected:", handled, err)
+	} else {
+		fmt.Println(handled)
+	}
+}
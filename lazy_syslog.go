@@ -0,0 +1,53 @@
+//go:build !windows && !plan9
+
+package lazyerrors
+
+import (
+	"errors"
+	"log/syslog"
+	"os"
+)
+
+// SyslogWriter - subset of *syslog.Writer methods used by CatchToSyslog.
+type SyslogWriter interface {
+	Crit(m string) error
+	Err(m string) error
+}
+
+var _ SyslogWriter = (*syslog.Writer)(nil)
+
+// CatchToSyslog - catches thrown error or panic like CatchAllWithStackFunc and writes it to the system logger w.
+//
+// Panics are written with LOG_CRIT priority and the full report, errors are written with LOG_ERR priority.
+// If w is nil (including a nil *syslog.Writer left by a failed syslog.New) or the write fails, the report
+// is written to os.Stderr instead.
+func CatchToSyslog(w SyslogWriter, ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		*ep = recoveredError(r)
+
+		if w == nil || writeSyslog(w, *ep) != nil {
+			WriteReport(os.Stderr, *ep)
+		}
+	}
+}
+
+// writeSyslog - writes error err to w with priority depending on whether err is a recovered panic, a panic
+// of w is returned as an error.
+func writeSyslog(w SyslogWriter, err error) (writeErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			writeErr = toError(r)
+		}
+	}()
+
+	var fromPanic *LazyErrorFromPanic
+	if errors.As(err, &fromPanic) {
+		return w.Crit(fromPanic.Report())
+	}
+
+	return w.Err(err.Error())
+}
@@ -0,0 +1,82 @@
+//go:build !windows && !plan9
+
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"log/syslog"
+	"strings"
+	"testing"
+)
+
+type testSyslog struct {
+	crit, err []string
+	fail      bool
+}
+
+func (w *testSyslog) Crit(m string) error {
+	w.crit = append(w.crit, m)
+
+	return w.error()
+}
+
+func (w *testSyslog) Err(m string) error {
+	w.err = append(w.err, m)
+
+	return w.error()
+}
+
+func (w *testSyslog) error() error {
+	if w.fail {
+		return errors.New("syslog is unavailable")
+	}
+
+	return nil
+}
+
+func TestCatchToSyslog(t *testing.T) {
+	var (
+		err error
+		w   = &testSyslog{}
+	)
+
+	for _, f := range []func() error{testFuncNoError, testFuncError, testFuncPanic} {
+		func() {
+			defer CatchToSyslog(w, &err)
+			Try(f())
+		}()
+	}
+
+	if len(w.err) != 1 || len(w.crit) != 1 || !strings.Contains(w.crit[0], "[stack]:") {
+		t.Fatal("unexpected:", w.err, w.crit)
+	} else {
+		fmt.Println(w.err, w.crit)
+	}
+
+	// failed writes fall back to stderr, the error is still caught.
+	w.fail, err = true, nil
+
+	func() {
+		defer CatchToSyslog(w, &err)
+		Try(testFuncPanic())
+	}()
+
+	if !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestCatchToSyslogNilWriter(t *testing.T) {
+	var err error
+
+	// callers hold a nil *syslog.Writer after syslog.New fails.
+	func() {
+		defer CatchToSyslog((*syslog.Writer)(nil), &err)
+		Try(testFuncPanic())
+	}()
+
+	if !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+}
@@ -0,0 +1,36 @@
+package lazyerrors
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// Categories of runtime/trace log events emitted by built-in try and catch handlers.
+const (
+	// TraceCategoryTry - category of events emitted for errors thrown by Try.
+	TraceCategoryTry = "lazyerrors.try"
+	// TraceCategoryCatch - category of events and regions emitted for errors caught by Catch.
+	TraceCategoryCatch = "lazyerrors.catch"
+	// TraceCategoryPanic - category of events and regions emitted for panics recovered by Catch.
+	TraceCategoryPanic = "lazyerrors.panic"
+)
+
+// traceThrow - emits log event of thrown error err if tracing is active.
+func traceThrow(err error) {
+	if trace.IsEnabled() {
+		trace.Log(context.Background(), TraceCategoryTry, Message(err))
+	}
+}
+
+// traceCatch - emits log event of caught error err and runs OnCatch hooks inside of a region.
+func traceCatch(err error, recovered bool) {
+	category := TraceCategoryCatch
+	if recovered {
+		category = TraceCategoryPanic
+	}
+
+	ctx := context.Background()
+
+	trace.Log(ctx, category, Message(err))
+	trace.WithRegion(ctx, category, func() { runCatchHooks(err, recovered) })
+}
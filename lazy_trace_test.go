@@ -0,0 +1,41 @@
+package lazyerrors
+
+import (
+	"bytes"
+	"fmt"
+	"runtime/trace"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	defer ResetHooks()
+
+	var (
+		buf    bytes.Buffer
+		hooked int
+	)
+
+	RegisterOnCatch(func(error, bool) { hooked++ })
+
+	if err := trace.Start(&buf); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	for _, f := range []func() error{testFuncNoError, testFuncError, testFuncPanic} {
+		_ = testWrapper(Try, Catch, f)
+	}
+
+	trace.Stop()
+
+	if hooked != 2 {
+		t.Fatal("unexpected:", hooked)
+	}
+
+	for _, category := range []string{TraceCategoryTry, TraceCategoryCatch, TraceCategoryPanic, "test panic"} {
+		if !bytes.Contains(buf.Bytes(), []byte(category)) {
+			t.Fatal("unexpected: no", category, "in trace")
+		}
+	}
+
+	fmt.Println(buf.Len())
+}
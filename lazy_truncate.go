@@ -0,0 +1,59 @@
+package lazyerrors
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+var (
+	// maxMessageLength - limit of messages, set with SetMaxMessageLength.
+	maxMessageLength atomic.Int64
+	// maxStackLength - limit of stacks, set with SetMaxStackLength.
+	maxStackLength atomic.Int64
+)
+
+// SetMaxMessageLength - sets maximum length n in bytes of formatted messages and recovered values,
+// 0 means unlimited (default).
+func SetMaxMessageLength(n int) {
+	maxMessageLength.Store(int64(n))
+}
+
+// SetMaxStackLength - sets maximum length n in bytes of formatted stacks, 0 means unlimited (default).
+func SetMaxStackLength(n int) {
+	maxStackLength.Store(int64(n))
+}
+
+// recovered - returns the recovered value formatted and truncated like messages.
+func (e *LazyErrorFromPanic) recovered() string {
+	return truncateMessage(fmt.Sprint(e.Recovered))
+}
+
+// truncatedStack - returns the stack truncated like stacks.
+func (e *LazyErrorFromPanic) truncatedStack() string {
+	return truncateStack(e.stack())
+}
+
+// truncateMessage - truncates message s to the limit set with SetMaxMessageLength.
+func truncateMessage(s string) string {
+	return truncate(s, int(maxMessageLength.Load()))
+}
+
+// truncateStack - truncates stack s to the limit set with SetMaxStackLength.
+func truncateStack(s string) string {
+	return truncate(s, int(maxStackLength.Load()))
+}
+
+// truncate - cuts s to limit bytes (keeping UTF-8 runes whole) and adds a suffix with the number of cut bytes.
+func truncate(s string, limit int) string {
+	if limit <= 0 || len(s) <= limit {
+		return s
+	}
+
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+
+	return fmt.Sprintf("%s... [truncated %d bytes]", s[:cut], len(s)-cut)
+}
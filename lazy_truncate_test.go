@@ -0,0 +1,32 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	defer SetMaxStackLength(0)
+	defer SetMaxMessageLength(0)
+
+	SetMaxMessageLength(8)
+	SetMaxStackLength(64)
+
+	err := testWrapper(TryWrapErrorFunc, CatchAllWithStackFunc, func() error { panic(strings.Repeat("x", 1024)) })
+	if msg := err.Error(); len(msg) > 256 || !strings.Contains(msg, "xxxxxxxx... [truncated 1016 bytes]") {
+		t.Fatal("unexpected:", msg)
+	} else {
+		fmt.Println(msg)
+	}
+
+	err = testWrapper(TryWrapErrorFunc, CatchAllFunc, func() error { return errors.New("test error") })
+	if msg := err.Error(); !strings.HasSuffix(msg, "test err... [truncated 2 bytes]") {
+		t.Fatal("unexpected:", msg)
+	}
+
+	if s := truncate("ééé", 3); s != "é... [truncated 4 bytes]" {
+		t.Fatal("unexpected:", s)
+	}
+}
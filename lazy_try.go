@@ -0,0 +1,160 @@
+package lazyerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// tryOrSink - handler of errors suppressed by TryOr, set with SetTryOrSink.
+var tryOrSink atomic.Pointer[func(err error)]
+
+// SetTryOrSink - sets optional handler fn of errors suppressed by TryOr, nil disables it (default).
+func SetTryOrSink(fn func(err error)) {
+	if fn == nil {
+		tryOrSink.Store(nil)
+
+		return
+	}
+
+	tryOrSink.Store(&fn)
+}
+
+// Try1 - checks error err with Try and returns value v.
+func Try1[T any](v T, err error) T {
+	Try(err)
+
+	return v
+}
+
+// Try2 - checks error err with Try and returns values a and b.
+func Try2[A, B any](a A, b B, err error) (A, B) {
+	Try(err)
+
+	return a, b
+}
+
+// Try3 - checks error err with Try and returns values a, b and c.
+func Try3[A, B, C any](a A, b B, c C, err error) (A, B, C) {
+	Try(err)
+
+	return a, b, c
+}
+
+// Try4 - checks error err with Try and returns values a, b, c and d.
+func Try4[A, B, C, D any](a A, b B, c C, d D, err error) (A, B, C, D) {
+	Try(err)
+
+	return a, b, c, d
+}
+
+// TryFunc - invokes fn, checks its error with Try and returns its value.
+func TryFunc[T any](fn func() (T, error)) T {
+	return Try1(fn())
+}
+
+// Must - checks error err with TryWrapErrorFunc regardless of Try and returns value v.
+func Must[T any](v T, err error) T {
+	TryWrapErrorFunc(err)
+
+	return v
+}
+
+// Must2 - checks error err with TryWrapErrorFunc regardless of Try and returns values a and b.
+func Must2[A, B any](a A, b B, err error) (A, B) {
+	TryWrapErrorFunc(err)
+
+	return a, b
+}
+
+// TryOr - returns value v, or fallback if error err is non-nil (err is passed to the handler set with SetTryOrSink).
+func TryOr[T any](v T, err error, fallback T) T {
+	if err != nil {
+		if fn := tryOrSink.Load(); fn != nil {
+			(*fn)(err)
+		}
+
+		return fallback
+	}
+
+	return v
+}
+
+// TrySlice - maps in with fn, the first error is annotated with the element index and checked with Try.
+func TrySlice[T, U any](in []T, fn func(T) (U, error)) []U {
+	out := make([]U, 0, len(in))
+
+	for i, v := range in {
+		u, err := fn(v)
+		if err != nil {
+			Try(fmt.Errorf("index %d: %w", i, err))
+		}
+
+		out = append(out, u)
+	}
+
+	return out
+}
+
+// TryMap - maps values of in with fn, the first error is annotated with the element key and checked with Try.
+func TryMap[K comparable, V, U any](in map[K]V, fn func(K, V) (U, error)) map[K]U {
+	out := make(map[K]U, len(in))
+
+	for k, v := range in {
+		u, err := fn(k, v)
+		if err != nil {
+			Try(fmt.Errorf("key %v: %w", k, err))
+		}
+
+		out[k] = u
+	}
+
+	return out
+}
+
+// TryOK - returns value v, or throws an error with message msg wrapped into LazyErrorWithCaller if ok is false.
+func TryOK[T any](v T, ok bool, msg string) T {
+	if !ok {
+		TryWrapErrorFunc(errors.New(msg))
+	}
+
+	return v
+}
+
+// TryCtx - checks error err with Try, if it's nil and ctx is done, the cause of ctx is checked with Try instead.
+//
+// The cause is tagged with Classify, so it matches ErrTimeout or ErrCanceled.
+func TryCtx(ctx context.Context, err error) {
+	if err != nil {
+		Try(err)
+
+		return
+	}
+
+	if ctx.Err() != nil {
+		Try(Classify(context.Cause(ctx)))
+	}
+}
+
+// TryAny - runs fallbacks fns in order with Any and checks the joined error of their failures with Try.
+func TryAny(fns ...func() error) {
+	Try(Any(fns...))
+}
+
+// Any - runs fallbacks fns in order under Catch until one succeeds and returns nil,
+// else returns errors.Join of their failures (recovered panics included) annotated with their index.
+func Any(fns ...func() error) error {
+	errs := make([]error, 0, len(fns))
+
+	for i, fn := range fns {
+		err := attempt(fn)
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, fmt.Errorf("fallback %d: %w", i, err))
+	}
+
+	return errors.Join(errs...)
+}
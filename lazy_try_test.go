@@ -0,0 +1,274 @@
+package lazyerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTry1(t *testing.T) {
+	f := func(err error) (int, error) {
+		return 42, err
+	}
+
+	var v int
+
+	if err := testWrapper(Try, Catch, func() error {
+		v = Try1(f(nil))
+
+		return nil
+	}); err != nil || v != 42 {
+		t.Fatal("unexpected:", v, err)
+	}
+
+	if err := testWrapper(Try, Catch, func() error {
+		v = Try1(f(errors.New("test error")))
+
+		return nil
+	}); err == nil {
+		t.Fatal("unexpected:", err)
+	} else if callerEnabled && !strings.Contains(err.Error(), "lazy_try_test.go") {
+		t.Fatal("unexpected caller:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestTryN(t *testing.T) {
+	f := func(err error) (int, string, bool, float64, error) {
+		return 1, "2", true, 4, err
+	}
+
+	if err := testWrapper(Try, Catch, func() error {
+		a, b := Try2(net.SplitHostPort("localhost:80"))
+		if a != "localhost" || b != "80" {
+			t.Fatal("unexpected:", a, b)
+		}
+
+		a1, b1, c1 := Try3(func() (int, string, bool, error) { return 1, "2", true, nil }())
+		if a1 != 1 || b1 != "2" || !c1 {
+			t.Fatal("unexpected:", a1, b1, c1)
+		}
+
+		a2, b2, c2, d2 := Try4(f(nil))
+		if a2 != 1 || b2 != "2" || !c2 || d2 != 4 {
+			t.Fatal("unexpected:", a2, b2, c2, d2)
+		}
+
+		return nil
+	}); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := testWrapper(Try, Catch, func() error {
+		Try2(net.SplitHostPort("localhost"))
+
+		return nil
+	}); err == nil {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	if err := testWrapper(Try, Catch, func() error {
+		Try4(f(errors.New("test error")))
+
+		return nil
+	}); err == nil {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestTryFunc(t *testing.T) {
+	var v string
+
+	if err := testWrapper(Try, Catch, func() error {
+		v = TryFunc(func() (string, error) { return "test", nil })
+
+		return nil
+	}); err != nil || v != "test" {
+		t.Fatal("unexpected:", v, err)
+	}
+
+	if err := testWrapper(Try, Catch, func() error {
+		v = TryFunc(func() (string, error) { return "", errors.New("test error") })
+
+		return nil
+	}); err == nil {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestMust(t *testing.T) {
+	if err := testWrapper(TryErrorFunc, CatchAllFunc, func() error {
+		Must(strconv.Atoi("test"))
+
+		return nil
+	}); err == nil {
+		t.Fatal("unexpected:", err)
+	} else if lazyErr := (*LazyErrorWithCaller)(nil); !errors.As(err, &lazyErr) {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	if err := testWrapper(TryErrorFunc, CatchAllFunc, func() error {
+		a, b := Must2(net.SplitHostPort("localhost:80"))
+		if a != "localhost" || b != "80" {
+			t.Fatal("unexpected:", a, b)
+		}
+
+		return nil
+	}); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestTryOr(t *testing.T) {
+	var suppressed []error
+
+	SetTryOrSink(func(err error) {
+		suppressed = append(suppressed, err)
+	})
+	defer SetTryOrSink(nil)
+
+	v, err := strconv.Atoi("42")
+	if v := TryOr(v, err, 1); v != 42 {
+		t.Fatal("unexpected:", v)
+	}
+
+	v, err = strconv.Atoi("test")
+	if v := TryOr(v, err, 1); v != 1 {
+		t.Fatal("unexpected:", v)
+	}
+
+	if len(suppressed) != 1 {
+		t.Fatal("unexpected:", suppressed)
+	} else {
+		fmt.Println(suppressed[0])
+	}
+}
+
+func TestTrySlice(t *testing.T) {
+	var out []int
+
+	if err := testWrapper(Try, Catch, func() error {
+		out = TrySlice([]string{"1", "2"}, strconv.Atoi)
+
+		return nil
+	}); err != nil || len(out) != 2 || out[1] != 2 {
+		t.Fatal("unexpected:", out, err)
+	}
+
+	if err := testWrapper(Try, Catch, func() error {
+		out = TrySlice([]string{"1", "test"}, strconv.Atoi)
+
+		return nil
+	}); err == nil || !strings.Contains(err.Error(), "index 1") {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestTryMap(t *testing.T) {
+	var out map[string]int
+
+	atoi := func(_ string, v string) (int, error) {
+		return strconv.Atoi(v)
+	}
+
+	if err := testWrapper(Try, Catch, func() error {
+		out = TryMap(map[string]string{"a": "1", "b": "2"}, atoi)
+
+		return nil
+	}); err != nil || len(out) != 2 || out["b"] != 2 {
+		t.Fatal("unexpected:", out, err)
+	}
+
+	if err := testWrapper(Try, Catch, func() error {
+		out = TryMap(map[string]string{"a": "test"}, atoi)
+
+		return nil
+	}); err == nil || !strings.Contains(err.Error(), "key a") {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestTryOK(t *testing.T) {
+	m := map[string]int{"a": 1}
+
+	if err := testWrapper(Try, Catch, func() error {
+		v, ok := m["a"]
+		if v = TryOK(v, ok, "missing a"); v != 1 {
+			t.Fatal("unexpected:", v)
+		}
+
+		return nil
+	}); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := testWrapper(TryErrorFunc, CatchAllFunc, func() error {
+		v, ok := m["b"]
+		TryOK(v, ok, "missing b")
+
+		return nil
+	}); err == nil || (callerEnabled && !strings.Contains(err.Error(), "lazy_try_test.go")) {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestTryCtx(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	if err := Do(func() { TryCtx(ctx, nil) }); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := Do(func() { TryCtx(ctx, io.EOF) }); !errors.Is(err, io.EOF) {
+		t.Fatal("unexpected:", err)
+	}
+
+	cancel(io.ErrClosedPipe)
+
+	if err := Do(func() { TryCtx(ctx, nil) }); !errors.Is(err, io.ErrClosedPipe) {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestTryAny(t *testing.T) {
+	calls := 0
+	failing := func() error { calls++; return testFuncError() }
+
+	if err := Do(func() { TryAny(failing, testFuncNoError, failing) }); err != nil || calls != 1 {
+		t.Fatal("unexpected:", err, calls)
+	}
+
+	err := Do(func() { TryAny(failing, testFuncPanic) })
+	if !errors.Is(err, ErrPanic) || !strings.Contains(err.Error(), "fallback 0: ") ||
+		!strings.Contains(err.Error(), "fallback 1: ") {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+
+	if err := Any(); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+}
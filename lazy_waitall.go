@@ -0,0 +1,46 @@
+package lazyerrors
+
+import (
+	"errors"
+	"sync"
+)
+
+// WaitAll - runs fns concurrently under Catch and returns errors.Join of all failures in the order of fns.
+func WaitAll(fns ...func() error) error {
+	return WaitAllN(0, fns...)
+}
+
+// WaitAllN - runs fns like WaitAll, but at most n of them at once (n <= 0 means no limit).
+func WaitAllN(n int, fns ...func() error) error {
+	var (
+		wg   sync.WaitGroup
+		sem  chan struct{}
+		errs = make([]error, len(fns))
+	)
+
+	if n > 0 {
+		sem = make(chan struct{}, n)
+	}
+
+	for i, fn := range fns {
+		if sem != nil {
+			sem <- struct{}{}
+		}
+
+		wg.Add(1)
+
+		go func(i int, fn func() error) {
+			defer wg.Done()
+
+			errs[i] = runSafe(fn)
+
+			if sem != nil {
+				<-sem
+			}
+		}(i, fn)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
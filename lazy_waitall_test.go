@@ -0,0 +1,46 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWaitAll(t *testing.T) {
+	if err := WaitAll(testFuncNoError, testFuncNoError); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	err := WaitAll(testFuncError, testFuncNoError, testFuncPanic)
+
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	if len(errs) != 2 || errors.Is(errs[0], ErrPanic) || !errors.Is(errs[1], ErrPanic) {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestWaitAllN(t *testing.T) {
+	var (
+		active, maxActive atomic.Int32
+		fns               []func() error
+	)
+
+	for i := 0; i < 10; i++ {
+		fns = append(fns, func() error {
+			n := active.Add(1)
+			defer active.Add(-1)
+
+			for m := maxActive.Load(); n > m && !maxActive.CompareAndSwap(m, n); m = maxActive.Load() {
+			}
+
+			return nil
+		})
+	}
+
+	if err := WaitAllN(3, fns...); err != nil || maxActive.Load() > 3 {
+		t.Fatal("unexpected:", err, maxActive.Load())
+	}
+}
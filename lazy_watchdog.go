@@ -0,0 +1,86 @@
+package lazyerrors
+
+import (
+	"sync"
+	"time"
+)
+
+// Watchdog - calls a callback when recovered panics exceed a threshold within a sliding time window.
+//
+// The callback is called at most once per window, so a failure storm doesn't flood it.
+type Watchdog struct {
+	threshold int
+	window    time.Duration
+	fn        func(panics int)
+	mu        sync.Mutex
+	times     []time.Time
+	fired     time.Time
+	now       func() time.Time
+}
+
+// NewWatchdog - returns Watchdog calling fn when more than threshold panics are recovered within window.
+func NewWatchdog(threshold int, window time.Duration, fn func(panics int)) *Watchdog {
+	return &Watchdog{
+		threshold: max(threshold, 0),
+		window:    window,
+		fn:        fn,
+		now:       time.Now,
+	}
+}
+
+// WatchPanics - returns Watchdog created with NewWatchdog and registers its Observe as OnCatch hook.
+func WatchPanics(threshold int, window time.Duration, fn func(panics int)) *Watchdog {
+	w := NewWatchdog(threshold, window, fn)
+	RegisterOnCatch(w.Observe)
+
+	return w
+}
+
+// Observe - OnCatch hook counting recovered panics, errors thrown by Try are ignored.
+func (w *Watchdog) Observe(_ error, recovered bool) {
+	if !recovered {
+		return
+	}
+
+	w.mu.Lock()
+
+	now := w.now()
+	w.trim(now)
+	w.times = append(w.times, now)
+
+	if len(w.times) > w.threshold+1 {
+		w.times = append(w.times[:0], w.times[1:]...)
+	}
+
+	if len(w.times) <= w.threshold || (!w.fired.IsZero() && now.Sub(w.fired) < w.window) {
+		w.mu.Unlock()
+
+		return
+	}
+
+	w.fired = now
+	panics := len(w.times)
+	w.mu.Unlock()
+
+	w.fn(panics)
+}
+
+// Count - returns number of panics recovered within the current window, it's capped at threshold+1.
+func (w *Watchdog) Count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.trim(w.now())
+
+	return len(w.times)
+}
+
+// trim - drops panics recovered before the current window.
+func (w *Watchdog) trim(now time.Time) {
+	i := 0
+	for i < len(w.times) && now.Sub(w.times[i]) >= w.window {
+		i++
+	}
+
+	w.times = append(w.times[:0], w.times[i:]...)
+}
@@ -0,0 +1,51 @@
+package lazyerrors
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWatchdog(t *testing.T) {
+	var (
+		calls []int
+		now   = time.Now()
+	)
+
+	w := NewWatchdog(2, time.Minute, func(panics int) { calls = append(calls, panics) })
+	w.now = func() time.Time { return now }
+
+	for _, step := range []time.Duration{0, time.Second, time.Second, time.Second, 0, time.Minute, 0, 0} {
+		now = now.Add(step)
+		w.Observe(testFuncError(), true)
+		w.Observe(testFuncError(), false)
+	}
+
+	if len(calls) != 2 || calls[0] != 3 || calls[1] != 3 || w.Count() != 3 {
+		t.Fatal("unexpected:", calls, w.Count())
+	} else {
+		fmt.Println(calls, w.Count())
+	}
+
+	now = now.Add(time.Minute)
+
+	if w.Count() != 0 {
+		t.Fatal("unexpected:", w.Count())
+	}
+}
+
+func TestWatchPanics(t *testing.T) {
+	defer ResetHooks()
+
+	fired := make(chan int, 1)
+
+	WatchPanics(1, time.Minute, func(panics int) { fired <- panics })
+
+	for _, f := range []func() error{testFuncPanic, testFuncError, testFuncPanic} {
+		_ = testWrapper(Try, Catch, f)
+	}
+
+	if panics := <-fired; panics != 2 {
+		t.Fatal("unexpected:", panics)
+	}
+}
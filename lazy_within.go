@@ -0,0 +1,81 @@
+package lazyerrors
+
+import (
+	"fmt"
+	"time"
+)
+
+// LazyErrorTimeout - custom error structure thrown by TryWithin when the function outlives its deadline.
+//
+// It matches ErrTimeout, the eventual outcome of the function is joined once it finishes.
+type LazyErrorTimeout struct {
+	After time.Duration
+	done  chan struct{}
+	err   error
+}
+
+// Error - error interface implementation, the outcome is added to the message if the function has failed by now.
+func (e *LazyErrorTimeout) Error() string {
+	msg := fmt.Sprintf("%s after %s", ErrTimeout, e.After)
+	if err := e.outcome(); err != nil {
+		msg += ": " + err.Error()
+	}
+
+	return msg
+}
+
+// Unwrap - error interface implementation, returns ErrTimeout and the outcome if the function has failed by now.
+func (e *LazyErrorTimeout) Unwrap() []error {
+	if err := e.outcome(); err != nil {
+		return []error{ErrTimeout, err}
+	}
+
+	return []error{ErrTimeout}
+}
+
+// Wait - blocks until the function is finished and returns its error (a recovered panic included).
+func (e *LazyErrorTimeout) Wait() error {
+	<-e.done
+
+	return e.err
+}
+
+// Done - returns a channel that is closed when the function is finished.
+func (e *LazyErrorTimeout) Done() <-chan struct{} {
+	return e.done
+}
+
+// outcome - returns error of the function if it's finished, nil otherwise.
+func (e *LazyErrorTimeout) outcome() error {
+	select {
+	case <-e.done:
+		return e.err
+	default:
+		return nil
+	}
+}
+
+// TryWithin - runs fn under Catch in a new goroutine and checks its error with Try if it finishes within duration d,
+// else throws LazyErrorTimeout leaving fn running. Useful for libraries ignoring contexts.
+func TryWithin(d time.Duration, fn func() error) {
+	e := &LazyErrorTimeout{
+		After: d,
+		done:  make(chan struct{}),
+	}
+
+	go func() {
+		defer close(e.done)
+
+		e.err = attempt(fn)
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-e.done:
+		Try(e.err)
+	case <-timer.C:
+		Try(e)
+	}
+}
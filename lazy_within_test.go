@@ -0,0 +1,47 @@
+package lazyerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTryWithin(t *testing.T) {
+	if err := Do(func() { TryWithin(time.Minute, testFuncNoError) }); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := Do(func() { TryWithin(time.Minute, testFuncPanic) }); !errors.Is(err, ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+
+	release := make(chan struct{})
+
+	err := Do(func() {
+		TryWithin(time.Millisecond, func() error {
+			<-release
+
+			return testFuncError()
+		})
+	})
+
+	var timeout *LazyErrorTimeout
+	if !errors.As(err, &timeout) || !errors.Is(err, ErrTimeout) || !IsTimeout(err) {
+		t.Fatal("unexpected:", err)
+	}
+
+	if timeout.outcome() != nil || timeout.Error() != "timeout after 1ms" {
+		t.Fatal("unexpected:", timeout)
+	}
+
+	close(release)
+
+	if outcome := timeout.Wait(); outcome == nil || !errors.Is(err, outcome) {
+		t.Fatal("unexpected:", outcome)
+	} else {
+		fmt.Println(err)
+	}
+
+	<-timeout.Done()
+}
@@ -0,0 +1,134 @@
+package lazygrpc
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+
+	"github.com/p-alexander/lazyerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// codeClasses - status codes of errors matching classes, used by Code.
+var codeClasses = []struct {
+	class error
+	code  codes.Code
+}{
+	{lazyerrors.ErrPermanent, codes.FailedPrecondition},
+	{lazyerrors.ErrRetryable, codes.Unavailable},
+	{lazyerrors.ErrInvalid, codes.InvalidArgument},
+	{lazyerrors.ErrTimeout, codes.DeadlineExceeded},
+	{lazyerrors.ErrCanceled, codes.Canceled},
+	{lazyerrors.ErrCircuitOpen, codes.Unavailable},
+	{fs.ErrNotExist, codes.NotFound},
+	{fs.ErrExist, codes.AlreadyExists},
+	{fs.ErrPermission, codes.PermissionDenied},
+}
+
+// classCodes - classes of errors with status codes, used by Class.
+var classCodes = map[codes.Code]error{
+	codes.FailedPrecondition: lazyerrors.ErrPermanent,
+	codes.Unimplemented:      lazyerrors.ErrPermanent,
+	codes.Unauthenticated:    lazyerrors.ErrPermanent,
+	codes.Unavailable:        lazyerrors.ErrRetryable,
+	codes.ResourceExhausted:  lazyerrors.ErrRetryable,
+	codes.Aborted:            lazyerrors.ErrRetryable,
+	codes.InvalidArgument:    lazyerrors.ErrInvalid,
+	codes.OutOfRange:         lazyerrors.ErrInvalid,
+	codes.DeadlineExceeded:   lazyerrors.ErrTimeout,
+	codes.Canceled:           lazyerrors.ErrCanceled,
+	codes.NotFound:           fs.ErrNotExist,
+	codes.AlreadyExists:      fs.ErrExist,
+	codes.PermissionDenied:   fs.ErrPermission,
+}
+
+// Code - returns status code of error err: the code of a status error in its chain, else the code of its class
+// (e.g. codes.DeadlineExceeded for lazyerrors.ErrTimeout, codes.InvalidArgument for lazyerrors.ErrInvalid,
+// codes.NotFound for fs.ErrNotExist), codes.Internal otherwise.
+func Code(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	if st, ok := grpcStatus(err); ok {
+		return st.Code()
+	}
+
+	err = lazyerrors.Classify(err)
+
+	for _, c := range codeClasses {
+		if errors.Is(err, c.class) {
+			return c.code
+		}
+	}
+
+	return codes.Internal
+}
+
+// Class - returns class of errors with status code code (e.g. lazyerrors.ErrTimeout for codes.DeadlineExceeded,
+// lazyerrors.ErrRetryable for codes.Unavailable), nil if there's none.
+func Class(code codes.Code) error {
+	return classCodes[code]
+}
+
+// FromStatus - tags status error err with the class of its code with lazyerrors.WithClass, so the client side
+// can branch on classification, other errors are returned as is.
+func FromStatus(err error) error {
+	if st, ok := grpcStatus(err); ok {
+		if class := Class(st.Code()); class != nil {
+			return lazyerrors.WithClass(err, class)
+		}
+	}
+
+	return err
+}
+
+// UnaryClientInterceptor - returns interceptor tagging status errors of unary calls with FromStatus.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		return FromStatus(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor - returns interceptor tagging status errors of streams with FromStatus.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, FromStatus(err)
+		}
+
+		return &clientStream{ClientStream: cs}, nil
+	}
+}
+
+// clientStream - grpc.ClientStream tagging status errors with FromStatus.
+type clientStream struct {
+	grpc.ClientStream
+}
+
+// SendMsg - grpc.ClientStream interface implementation.
+func (s *clientStream) SendMsg(m interface{}) error {
+	return FromStatus(s.ClientStream.SendMsg(m))
+}
+
+// RecvMsg - grpc.ClientStream interface implementation.
+func (s *clientStream) RecvMsg(m interface{}) error {
+	return FromStatus(s.ClientStream.RecvMsg(m))
+}
+
+// grpcStatus - returns status of the outermost status error in the chain of err.
+func grpcStatus(err error) (*status.Status, bool) {
+	var e interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &e) {
+		return e.GRPCStatus(), true
+	}
+
+	return nil, false
+}
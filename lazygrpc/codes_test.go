@@ -0,0 +1,106 @@
+package lazygrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/p-alexander/lazyerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCode(t *testing.T) {
+	for _, testCase := range []struct {
+		err  error
+		code codes.Code
+	}{
+		{nil, codes.OK},
+		{io.EOF, codes.Internal},
+		{fmt.Errorf("query: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{context.Canceled, codes.Canceled},
+		{lazyerrors.Invalid(io.EOF), codes.InvalidArgument},
+		{lazyerrors.Retryable(io.EOF), codes.Unavailable},
+		{lazyerrors.Permanent(io.EOF), codes.FailedPrecondition},
+		{fmt.Errorf("db: %w", lazyerrors.ErrCircuitOpen), codes.Unavailable},
+		{fmt.Errorf("user: %w", fs.ErrNotExist), codes.NotFound},
+		{lazyerrors.Do(func() { lazyerrors.Try(status.Error(codes.Aborted, "aborted")) }), codes.Aborted},
+		{lazyerrors.Do(func() { panic("test panic") }), codes.Internal},
+	} {
+		if code := Code(testCase.err); code != testCase.code {
+			t.Fatal("unexpected:", testCase.err, code)
+		}
+	}
+}
+
+func TestFromStatus(t *testing.T) {
+	for _, testCase := range []struct {
+		code  codes.Code
+		class error
+	}{
+		{codes.DeadlineExceeded, lazyerrors.ErrTimeout},
+		{codes.Canceled, lazyerrors.ErrCanceled},
+		{codes.Unavailable, lazyerrors.ErrRetryable},
+		{codes.InvalidArgument, lazyerrors.ErrInvalid},
+		{codes.NotFound, fs.ErrNotExist},
+		{codes.Unimplemented, lazyerrors.ErrPermanent},
+	} {
+		err := FromStatus(status.Error(testCase.code, "test"))
+		if !errors.Is(err, testCase.class) || status.Code(err) != testCase.code || Code(err) != testCase.code {
+			t.Fatal("unexpected:", err)
+		}
+	}
+
+	if err := FromStatus(status.Error(codes.Internal, "test")); lazyerrors.Class(err) != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := FromStatus(io.EOF); err != io.EOF {
+		t.Fatal("unexpected:", err)
+	}
+
+	if !lazyerrors.IsRetryable(FromStatus(status.Error(codes.DeadlineExceeded, "test"))) {
+		t.Fatal("unexpected")
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	err := UnaryClientInterceptor()(context.Background(), "/test", nil, nil, nil,
+		func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+			return status.Error(codes.Unavailable, "down")
+		},
+	)
+
+	if !lazyerrors.IsRetryable(err) {
+		t.Fatal("unexpected:", err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+type testClientStream struct {
+	grpc.ClientStream
+}
+
+func (testClientStream) RecvMsg(interface{}) error {
+	return status.Error(codes.DeadlineExceeded, "slow")
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	cs, err := StreamClientInterceptor()(context.Background(), &grpc.StreamDesc{}, nil, "/test",
+		func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+			return testClientStream{}, nil
+		},
+	)
+	if err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := cs.RecvMsg(nil); !errors.Is(err, lazyerrors.ErrTimeout) {
+		t.Fatal("unexpected:", err)
+	}
+}
@@ -0,0 +1,168 @@
+// Package lazygrpc - contains gRPC server interceptors running handlers under lazyerrors.Catch.
+//
+// Thrown errors and recovered panics are converted into status errors, so handlers can use Try freely.
+// Client interceptors tag status errors with error classes, see FromStatus.
+//
+//	server := grpc.NewServer(
+//	        grpc.ChainUnaryInterceptor(logging, lazygrpc.UnaryServerInterceptor()),
+//	        grpc.ChainStreamInterceptor(lazygrpc.StreamServerInterceptor()),
+//	)
+package lazygrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/p-alexander/lazyerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	// Option - option of interceptors.
+	Option func(c *config)
+	// config - settings of interceptors.
+	config struct {
+		code    func(err error) codes.Code
+		message func(err error, code codes.Code) string
+	}
+	// errorKey - context key of errorSlot set by interceptors or ErrorContext.
+	errorKey struct{}
+	// errorSlot - slot of the error caught while handling a call.
+	errorSlot struct {
+		err error
+	}
+)
+
+// WithCodeMapper - sets mapper fn of caught errors to status codes, nil is ignored. Code is used by default.
+func WithCodeMapper(fn func(err error) codes.Code) Option {
+	return func(c *config) {
+		if fn != nil {
+			c.code = fn
+		}
+	}
+}
+
+// WithMessageMapper - sets mapper fn of caught errors to status messages sent to clients, nil is ignored.
+//
+// By default only status errors keep their messages, others get the name of their code, so internal messages
+// (e.g. of database drivers) don't leak to clients. Message can be used to forward messages of non-internal errors.
+func WithMessageMapper(fn func(err error, code codes.Code) string) Option {
+	return func(c *config) {
+		if fn != nil {
+			c.message = fn
+		}
+	}
+}
+
+// Message - returns message of error err for clients: lazyerrors.Message of it, or the name of code if it's
+// codes.Internal or codes.Unknown or err is a recovered panic.
+func Message(err error, code codes.Code) string {
+	if code == codes.Internal || code == codes.Unknown || errors.Is(err, lazyerrors.ErrPanic) {
+		return code.String()
+	}
+
+	return lazyerrors.Message(err)
+}
+
+// UnaryServerInterceptor - returns interceptor running unary handlers under Catch, returned or thrown errors and
+// panics are converted into status errors. The caught error is available to interceptors with ErrorFromContext.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	c := newConfig(opts)
+
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+		resp interface{}, err error,
+	) {
+		ctx, slot := withErrorSlot(ctx)
+
+		resp, err = lazyerrors.Do1(func() interface{} { return lazyerrors.Try1(handler(ctx, req)) })
+		if err != nil {
+			return nil, c.toStatus(slot, err)
+		}
+
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor - returns interceptor running stream handlers under Catch like UnaryServerInterceptor.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	c := newConfig(opts)
+
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, slot := withErrorSlot(ss.Context())
+		ss = &serverStream{ServerStream: ss, ctx: ctx}
+
+		if err := lazyerrors.Do(func() { lazyerrors.Try(handler(srv, ss)) }); err != nil {
+			return c.toStatus(slot, err)
+		}
+
+		return nil
+	}
+}
+
+// ErrorContext - returns a copy of ctx able to carry the error caught by interceptors, so interceptors
+// called before them can get it with ErrorFromContext after the call is handled.
+func ErrorContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, errorKey{}, &errorSlot{})
+}
+
+// ErrorFromContext - returns error caught by interceptors while handling the call of ctx, nil if there's none.
+func ErrorFromContext(ctx context.Context) error {
+	if slot, ok := ctx.Value(errorKey{}).(*errorSlot); ok {
+		return slot.err
+	}
+
+	return nil
+}
+
+// newConfig - returns config with opts applied.
+func newConfig(opts []Option) *config {
+	c := &config{code: Code, message: codeMessage}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// toStatus - records caught error err into slot and converts it into a status error.
+//
+// Status errors are returned as is unless their code is mapped to another one.
+func (c *config) toStatus(slot *errorSlot, err error) error {
+	slot.err = err
+
+	code := c.code(err)
+	if st, ok := grpcStatus(err); ok && st.Code() == code {
+		return st.Err()
+	}
+
+	return status.Error(code, c.message(err, code))
+}
+
+// codeMessage - default message mapper, returns the name of code.
+func codeMessage(_ error, code codes.Code) string {
+	return code.String()
+}
+
+// withErrorSlot - returns ctx carrying slot of the caught error, the slot ctx already carries is reused.
+func withErrorSlot(ctx context.Context) (context.Context, *errorSlot) {
+	if slot, ok := ctx.Value(errorKey{}).(*errorSlot); ok {
+		return ctx, slot
+	}
+
+	slot := &errorSlot{}
+
+	return context.WithValue(ctx, errorKey{}, slot), slot
+}
+
+// serverStream - grpc.ServerStream carrying the context with slot of the caught error.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context - grpc.ServerStream interface implementation.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,104 @@
+package lazygrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/p-alexander/lazyerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+
+	for _, testCase := range []struct {
+		f    func() error
+		code codes.Code
+		msg  string
+	}{
+		{func() error { return nil }, codes.OK, ""},
+		{func() error { return errors.New("db: password=secret") }, codes.Internal, "Internal"},
+		{func() error { return lazyerrors.Invalid(errors.New("bad id")) }, codes.InvalidArgument, "InvalidArgument"},
+		{func() error { lazyerrors.Try(status.Error(codes.NotFound, "no user")); return nil }, codes.NotFound, "no user"},
+		{func() error { panic("test panic") }, codes.Internal, "Internal"},
+	} {
+		ctx := ErrorContext(context.Background())
+
+		resp, err := interceptor(ctx, "req", &grpc.UnaryServerInfo{}, func(context.Context, interface{}) (interface{}, error) {
+			return "resp", testCase.f()
+		})
+
+		st := status.Convert(err)
+		if st.Code() != testCase.code || st.Message() != testCase.msg || (err == nil) != (resp == "resp") ||
+			(err == nil) != (ErrorFromContext(ctx) == nil) {
+			t.Fatal("unexpected:", resp, err)
+		} else if err != nil {
+			fmt.Println(err, "|", ErrorFromContext(ctx))
+		}
+	}
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	interceptor := StreamServerInterceptor(WithCodeMapper(func(err error) codes.Code {
+		if errors.Is(err, lazyerrors.ErrPanic) {
+			return codes.Unavailable
+		}
+
+		return codes.Unknown
+	}))
+
+	ctx := ErrorContext(context.Background())
+
+	err := interceptor(nil, &testStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(_ interface{}, ss grpc.ServerStream) error {
+		if ErrorFromContext(ss.Context()) != nil {
+			return errors.New("unexpected error")
+		}
+
+		panic("test panic")
+	})
+
+	if status.Code(err) != codes.Unavailable || !errors.Is(ErrorFromContext(ctx), lazyerrors.ErrPanic) {
+		t.Fatal("unexpected:", err)
+	}
+
+	if err := interceptor(nil, &testStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(interface{}, grpc.ServerStream) error {
+		return nil
+	}); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+}
+
+func TestWithMessageMapper(t *testing.T) {
+	interceptor := UnaryServerInterceptor(WithMessageMapper(Message))
+
+	for _, testCase := range []struct {
+		err  error
+		code codes.Code
+		msg  string
+	}{
+		{lazyerrors.Invalid(errors.New("bad id")), codes.InvalidArgument, "bad id"},
+		{errors.New("db: password=secret"), codes.Internal, "Internal"},
+		{status.Error(codes.NotFound, "no user"), codes.NotFound, "no user"},
+	} {
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(context.Context, interface{}) (interface{}, error) {
+			return nil, testCase.err
+		})
+
+		if st := status.Convert(err); st.Code() != testCase.code || st.Message() != testCase.msg {
+			t.Fatal("unexpected:", err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Package lazyotel - contains OpenTelemetry integration of lazyerrors package.
+//
+// Defer CatchSpan to record caught errors and recovered panics onto the active span.
+//
+//	func handle(ctx context.Context) (err error) {
+//	        ctx, span := tracer.Start(ctx, "handle")
+//	        defer span.End()
+//	        defer lazyotel.CatchSpan(ctx, &err)
+//	        lazyerrors.Try(bar(ctx))
+//
+//	        return
+//	}
+package lazyotel
+
+import (
+	"context"
+
+	"github.com/p-alexander/lazyerrors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CatchSpan - catches thrown error or panic like CatchAllWithStackFunc, assigns it and records it onto the span of ctx.
+//
+// The error is recorded with RecordError and the span status is set to codes.Error.
+func CatchSpan(ctx context.Context, ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		*ep = lazyerrors.FromRecovered(r)
+		RecordError(trace.SpanFromContext(ctx), *ep)
+	}
+}
+
+// RecordError - records error err onto span with stack, caller and panic attributes and sets span status to codes.Error.
+//
+// The stack captured by this package is used as "exception.stacktrace" instead of the stack of the recording site.
+func RecordError(span trace.Span, err error) {
+	if err == nil || !span.IsRecording() {
+		return
+	}
+
+	span.RecordError(err, trace.WithAttributes(Attributes(err)...))
+	span.SetStatus(codes.Error, lazyerrors.Message(err))
+}
+
+// Attributes - returns span attributes of error err converted from lazyerrors.LogAttrs (the message is skipped).
+func Attributes(err error) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+
+	for _, attr := range lazyerrors.LogAttrs(err) {
+		switch attr.Key {
+		// the message is recorded by RecordError itself.
+		case "error":
+		case "stack":
+			attrs = append(attrs, attribute.String("exception.stacktrace", attr.Value.String()))
+		default:
+			attrs = append(attrs, attribute.String("lazyerrors."+attr.Key, attr.Value.String()))
+		}
+	}
+
+	return attrs
+}
@@ -0,0 +1,61 @@
+package lazyotel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/p-alexander/lazyerrors"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestCatchSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	for _, f := range []func() error{
+		func() error { return nil },
+		func() error { return errors.New("test error") },
+		func() error { panic("test panic") },
+	} {
+		func() {
+			var err error
+
+			ctx, span := tracer.Start(context.Background(), "test")
+			defer span.End()
+			defer CatchSpan(ctx, &err)
+			lazyerrors.Try(f())
+		}()
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 || spans[0].Status().Code != codes.Unset || len(spans[0].Events()) != 0 {
+		t.Fatal("unexpected:", spans)
+	}
+
+	for i, expected := range []string{"lazyerrors.caller", "lazyerrors.panic"} {
+		span := spans[i+1]
+		if span.Status().Code != codes.Error || len(span.Events()) != 1 {
+			t.Fatal("unexpected:", span.Status(), span.Events())
+		}
+
+		attrs := map[string]string{}
+		for _, attr := range span.Events()[0].Attributes {
+			attrs[string(attr.Key)] = attr.Value.Emit()
+		}
+
+		if attrs[expected] == "" && (lazyerrors.CapturesCaller() || expected != "lazyerrors.caller") {
+			t.Fatal("unexpected:", attrs)
+		}
+
+		if i == 1 && !strings.Contains(attrs["exception.stacktrace"], "TestCatchSpan") {
+			t.Fatal("unexpected:", attrs)
+		} else {
+			fmt.Println(span.Status(), attrs[expected])
+		}
+	}
+}
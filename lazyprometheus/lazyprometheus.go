@@ -0,0 +1,79 @@
+// Package lazyprometheus - contains Prometheus implementation of lazyerrors.Metrics.
+//
+// Register the collector with a Prometheus registry and with lazyerrors once.
+//
+//	metrics := lazyprometheus.New("myapp")
+//	prometheus.MustRegister(metrics)
+//	lazyerrors.RegisterMetrics(metrics)
+package lazyprometheus
+
+import (
+	"github.com/p-alexander/lazyerrors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// labels - label names of all counters: error type and call-site package.
+var labels = []string{"type", "package"}
+
+// Metrics - lazyerrors.Metrics and prometheus.Collector implementation.
+type Metrics struct {
+	throws  *prometheus.CounterVec
+	catches *prometheus.CounterVec
+	panics  *prometheus.CounterVec
+}
+
+var (
+	_ lazyerrors.Metrics   = (*Metrics)(nil)
+	_ prometheus.Collector = (*Metrics)(nil)
+)
+
+// New - returns Metrics with counters in a given namespace and "lazyerrors" subsystem.
+func New(namespace string) *Metrics {
+	counter := func(name, help string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "lazyerrors",
+			Name:      name,
+			Help:      help,
+		}, labels)
+	}
+
+	return &Metrics{
+		throws:  counter("throws_total", "Number of errors thrown by Try."),
+		catches: counter("catches_total", "Number of errors caught by Catch, recovered panics included."),
+		panics:  counter("panics_recovered_total", "Number of panics recovered by Catch."),
+	}
+}
+
+// ObserveThrow - lazyerrors.Metrics interface implementation.
+func (m *Metrics) ObserveThrow(err error) {
+	m.throws.WithLabelValues(values(err)...).Inc()
+}
+
+// ObserveCatch - lazyerrors.Metrics interface implementation.
+func (m *Metrics) ObserveCatch(err error, recovered bool) {
+	m.catches.WithLabelValues(values(err)...).Inc()
+
+	if recovered {
+		m.panics.WithLabelValues(values(err)...).Inc()
+	}
+}
+
+// Describe - prometheus.Collector interface implementation.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.throws.Describe(ch)
+	m.catches.Describe(ch)
+	m.panics.Describe(ch)
+}
+
+// Collect - prometheus.Collector interface implementation.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.throws.Collect(ch)
+	m.catches.Collect(ch)
+	m.panics.Collect(ch)
+}
+
+// values - returns label values of error err.
+func values(err error) []string {
+	return []string{lazyerrors.ErrorType(err), lazyerrors.ErrorPackage(err)}
+}
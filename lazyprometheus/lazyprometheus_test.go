@@ -0,0 +1,61 @@
+package lazyprometheus
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/p-alexander/lazyerrors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	metrics := New("test")
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(metrics)
+
+	lazyerrors.RegisterMetrics(metrics)
+	defer lazyerrors.ResetHooks()
+
+	for _, f := range []func() error{
+		func() error { return nil },
+		func() error { return errors.New("test error") },
+		func() error { panic("test panic") },
+	} {
+		func() {
+			var err error
+
+			defer lazyerrors.Catch(&err)
+			lazyerrors.Try(f())
+		}()
+	}
+
+	// package of thrown errors comes from their caller, panics always carry the stack.
+	pkg, throwPkg := "github.com/p-alexander/lazyerrors/lazyprometheus", ""
+	if lazyerrors.CapturesCaller() {
+		throwPkg = pkg
+	}
+
+	expected := fmt.Sprintf(`
+# HELP test_lazyerrors_panics_recovered_total Number of panics recovered by Catch.
+# TYPE test_lazyerrors_panics_recovered_total counter
+test_lazyerrors_panics_recovered_total{package="%[1]s",type="panic"} 1
+# HELP test_lazyerrors_throws_total Number of errors thrown by Try.
+# TYPE test_lazyerrors_throws_total counter
+test_lazyerrors_throws_total{package="%[2]s",type="*errors.errorString"} 1
+`, pkg, throwPkg)
+
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"test_lazyerrors_panics_recovered_total", "test_lazyerrors_throws_total"); err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	if n := testutil.CollectAndCount(metrics, "test_lazyerrors_catches_total"); n != 2 {
+		t.Fatal("unexpected:", n)
+	} else {
+		fmt.Println(n)
+	}
+}
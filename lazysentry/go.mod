@@ -0,0 +1,15 @@
+module github.com/p-alexander/lazyerrors/lazysentry
+
+go 1.21
+
+require (
+	github.com/getsentry/sentry-go v0.28.1
+	github.com/p-alexander/lazyerrors v0.0.0
+)
+
+require (
+	golang.org/x/sys v0.18.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
+
+replace github.com/p-alexander/lazyerrors => ../
@@ -0,0 +1,87 @@
+// Package lazysentry - contains Sentry implementation of lazyerrors.Reporter.
+//
+// Register the reporter once, so every built-in catch handler forwards recovered panics to Sentry
+// with the stack of the panic site rather than the stack of the recover site.
+//
+//	lazyerrors.RegisterReporter(lazysentry.New(sentry.CurrentHub()))
+package lazysentry
+
+import (
+	"runtime"
+	"strconv"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/p-alexander/lazyerrors"
+)
+
+// Reporter - lazyerrors.Reporter implementation that sends events to Sentry.
+type Reporter struct {
+	// Hub - Sentry hub events are sent to, sentry.CurrentHub is used if nil.
+	Hub *sentry.Hub
+	// Errors - sends caught errors too, only recovered panics are sent by default.
+	Errors bool
+}
+
+// New - returns Reporter sending recovered panics to hub.
+func New(hub *sentry.Hub) *Reporter {
+	return &Reporter{Hub: hub}
+}
+
+// CaptureError - lazyerrors.Reporter interface implementation.
+func (r *Reporter) CaptureError(err error) {
+	if r.Errors {
+		r.hub().CaptureException(err)
+	}
+}
+
+// CapturePanic - lazyerrors.Reporter interface implementation.
+func (r *Reporter) CapturePanic(err *lazyerrors.LazyErrorFromPanic) {
+	event := sentry.NewEvent()
+	event.Level = sentry.LevelFatal
+	event.Message = lazyerrors.Message(err)
+	event.Fingerprint = []string{lazyerrors.Fingerprint(err)}
+	event.Exception = []sentry.Exception{{
+		Type:       err.Kind.String(),
+		Value:      lazyerrors.Message(err),
+		Stacktrace: stacktrace(err.Frames()),
+	}}
+
+	for key, value := range err.Labels {
+		event.Tags[key] = value
+	}
+
+	if err.GoroutineID != 0 {
+		event.Tags["goroutine"] = strconv.FormatUint(err.GoroutineID, 10)
+	}
+
+	r.hub().CaptureEvent(event)
+}
+
+// hub - returns configured hub or the current one.
+func (r *Reporter) hub() *sentry.Hub {
+	if r.Hub != nil {
+		return r.Hub
+	}
+
+	return sentry.CurrentHub()
+}
+
+// stacktrace - converts frames (innermost first) into Sentry stacktrace (outermost first).
+func stacktrace(frames []lazyerrors.Frame) *sentry.Stacktrace {
+	if len(frames) == 0 {
+		return nil
+	}
+
+	st := &sentry.Stacktrace{Frames: make([]sentry.Frame, 0, len(frames))}
+
+	for i := len(frames) - 1; i >= 0; i-- {
+		st.Frames = append(st.Frames, sentry.NewFrame(runtime.Frame{
+			PC:       frames[i].PC,
+			Function: frames[i].Function,
+			File:     frames[i].File,
+			Line:     frames[i].Line,
+		}))
+	}
+
+	return st
+}
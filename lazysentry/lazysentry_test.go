@@ -0,0 +1,68 @@
+package lazysentry
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/p-alexander/lazyerrors"
+)
+
+type testTransport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *testTransport) Flush(time.Duration) bool { return true }
+
+func (t *testTransport) Configure(sentry.ClientOptions) {}
+
+func (t *testTransport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.events = append(t.events, event)
+}
+
+func TestReporter(t *testing.T) {
+	transport := &testTransport{}
+
+	client, err := sentry.NewClient(sentry.ClientOptions{Transport: transport})
+	if err != nil {
+		t.Fatal("unexpected:", err)
+	}
+
+	lazyerrors.RegisterReporter(&Reporter{Hub: sentry.NewHub(client, sentry.NewScope()), Errors: true})
+	defer lazyerrors.ResetHooks()
+
+	for _, f := range []func() error{
+		func() error { return errors.New("test error") },
+		func() error { panic("test panic") },
+	} {
+		func() {
+			var err error
+
+			defer lazyerrors.Catch(&err)
+			lazyerrors.Try(f())
+		}()
+	}
+
+	if len(transport.events) != 2 {
+		t.Fatal("unexpected:", transport.events)
+	}
+
+	event := transport.events[1]
+	if event.Level != sentry.LevelFatal || len(event.Exception) != 1 || event.Exception[0].Value != "test panic" || len(event.Fingerprint) != 1 {
+		t.Fatal("unexpected:", event)
+	}
+
+	frames := event.Exception[0].Stacktrace.Frames
+	if last := frames[len(frames)-1]; last.Function != "TestReporter.func2" {
+		t.Fatal("unexpected:", last)
+	} else {
+		fmt.Printf("%+v\n", last)
+	}
+}
@@ -0,0 +1,85 @@
+// Package lazyzap - contains zap integration of lazyerrors package.
+//
+// Use Error to log lazy errors with their caller, stack and panic information as structured fields.
+//
+//	logger.Error("request failed", lazyzap.Error(err))
+//
+// Or defer CatchAndLog to catch and log errors in one step.
+//
+//	func foo(logger *zap.Logger) (err error) {
+//	        defer lazyzap.CatchAndLog(logger, &err)
+//	        lazyerrors.Try(bar())
+//
+//	        return
+//	}
+package lazyzap
+
+import (
+	"errors"
+
+	"github.com/p-alexander/lazyerrors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Marshaler - zapcore.ObjectMarshaler of lazy errors.
+type Marshaler struct {
+	Err error
+}
+
+// MarshalLogObject - zapcore.ObjectMarshaler interface implementation.
+func (m Marshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, attr := range lazyerrors.LogAttrs(m.Err) {
+		enc.AddString(attr.Key, attr.Value.String())
+	}
+
+	return nil
+}
+
+// Error - returns zap field "error" with structured information of error err.
+func Error(err error) zap.Field {
+	return NamedError("error", err)
+}
+
+// NamedError - returns zap field key with structured information of error err.
+func NamedError(key string, err error) zap.Field {
+	if err == nil {
+		return zap.Skip()
+	}
+
+	return zap.Object(key, Marshaler{Err: err})
+}
+
+// CatchAndLog - catches thrown error or panic like lazyerrors.CatchAllWithStackFunc, assigns it and logs it with logger.
+//
+// Panics are logged with zapcore.ErrorLevel, errors are logged with lazyerrors.ErrorLogLevel() mapped to zap.
+func CatchAndLog(logger *zap.Logger, ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		*ep = lazyerrors.FromRecovered(r)
+
+		if logger != nil {
+			logError(logger, *ep)
+		}
+	}
+}
+
+// logError - logs error err with logger, level depends on whether err is a recovered panic.
+func logError(logger *zap.Logger, err error) {
+	if errors.Is(err, lazyerrors.ErrPanic) {
+		logger.Error("panic recovered", Error(err))
+
+		return
+	}
+
+	level := zapcore.ErrorLevel
+	// slog levels are multiples of 4 with info at 0, zap levels are sequential with info at 0.
+	if l := zapcore.Level(lazyerrors.ErrorLogLevel() / 4); l >= zapcore.DebugLevel && l <= zapcore.FatalLevel {
+		level = l
+	}
+
+	logger.Log(level, "error caught", Error(err))
+}
@@ -0,0 +1,49 @@
+package lazyzap
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/p-alexander/lazyerrors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCatchAndLog(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core)
+
+	for _, f := range []func() error{
+		func() error { return nil },
+		func() error { return errors.New("test error") },
+		func() error { panic("test panic") },
+	} {
+		var err error
+
+		func() {
+			defer CatchAndLog(logger, &err)
+			lazyerrors.Try(f())
+		}()
+	}
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatal("unexpected:", entries)
+	}
+
+	for _, entry := range entries {
+		fmt.Println(entry.Message, entry.ContextMap())
+	}
+
+	fields, ok := entries[0].ContextMap()["error"].(map[string]interface{})
+	if !ok || fields["error"] != "test error" || (lazyerrors.CapturesCaller() && fields["caller"] == nil) || entries[0].Level != zapcore.ErrorLevel {
+		t.Fatal("unexpected:", entries[0].ContextMap())
+	}
+
+	fields, ok = entries[1].ContextMap()["error"].(map[string]interface{})
+	if !ok || fields["panic"] != "test panic" || fields["stack"] == nil {
+		t.Fatal("unexpected:", entries[1].ContextMap())
+	}
+}
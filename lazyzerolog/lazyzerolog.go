@@ -0,0 +1,69 @@
+// Package lazyzerolog - contains zerolog integration of lazyerrors package.
+//
+// Use Enrich to add caller, stack and panic information of lazy errors to an event.
+//
+//	lazyzerolog.Enrich(logger.Error(), err).Msg("request failed")
+//
+// Or defer CatchAndLog to catch and log errors in one step.
+//
+//	func foo(logger *zerolog.Logger) (err error) {
+//	        defer lazyzerolog.CatchAndLog(logger, &err)
+//	        lazyerrors.Try(bar())
+//
+//	        return
+//	}
+package lazyzerolog
+
+import (
+	"errors"
+
+	"github.com/p-alexander/lazyerrors"
+	"github.com/rs/zerolog"
+)
+
+// Enrich - adds structured information of error err to event e and returns it.
+func Enrich(e *zerolog.Event, err error) *zerolog.Event {
+	if e == nil || err == nil {
+		return e
+	}
+
+	for _, attr := range lazyerrors.LogAttrs(err) {
+		e = e.Str(attr.Key, attr.Value.String())
+	}
+
+	return e
+}
+
+// CatchAndLog - catches thrown error or panic like lazyerrors.CatchAllWithStackFunc, assigns it and logs it with logger.
+//
+// Panics are logged with zerolog.ErrorLevel, errors are logged with lazyerrors.ErrorLogLevel() mapped to zerolog.
+func CatchAndLog(logger *zerolog.Logger, ep *error) {
+	if ep == nil {
+		return
+	}
+	// recover from panic.
+	if r := recover(); r != nil {
+		*ep = lazyerrors.FromRecovered(r)
+
+		if logger != nil {
+			logError(logger, *ep)
+		}
+	}
+}
+
+// logError - logs error err with logger, level depends on whether err is a recovered panic.
+func logError(logger *zerolog.Logger, err error) {
+	if errors.Is(err, lazyerrors.ErrPanic) {
+		Enrich(logger.Error(), err).Msg("panic recovered")
+
+		return
+	}
+
+	level := zerolog.ErrorLevel
+	// slog levels are multiples of 4 with info at 0, zerolog levels are sequential with info at 1.
+	if l := zerolog.Level(lazyerrors.ErrorLogLevel()/4 + 1); l >= zerolog.DebugLevel && l <= zerolog.ErrorLevel {
+		level = l
+	}
+
+	Enrich(logger.WithLevel(level), err).Msg("error caught")
+}
@@ -0,0 +1,55 @@
+package lazyzerolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/p-alexander/lazyerrors"
+	"github.com/rs/zerolog"
+)
+
+func TestCatchAndLog(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := zerolog.New(&buf)
+
+	for _, f := range []func() error{
+		func() error { return nil },
+		func() error { return errors.New("test error") },
+		func() error { panic("test panic") },
+	} {
+		var err error
+
+		func() {
+			defer CatchAndLog(&logger, &err)
+			lazyerrors.Try(f())
+		}()
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatal("unexpected:", lines)
+	}
+
+	var records [2]map[string]interface{}
+
+	for i, line := range lines {
+		if err := json.Unmarshal([]byte(line), &records[i]); err != nil {
+			t.Fatal("unexpected:", err)
+		}
+
+		fmt.Println(line)
+	}
+
+	if records[0]["level"] != "error" || records[0]["error"] != "test error" || (lazyerrors.CapturesCaller() && records[0]["caller"] == nil) {
+		t.Fatal("unexpected:", records[0])
+	}
+
+	if records[1]["message"] != "panic recovered" || records[1]["panic"] != "test panic" || records[1]["stack"] == nil {
+		t.Fatal("unexpected:", records[1])
+	}
+}